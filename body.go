@@ -0,0 +1,146 @@
+package openswag
+
+import (
+	"reflect"
+
+	"github.com/andrianprasetya/open-swag-go/pkg/spec"
+)
+
+// Request body content types
+const (
+	ContentTypeJSON       = "application/json"
+	ContentTypeURLEncoded = "application/x-www-form-urlencoded"
+	ContentTypeMultipart  = "multipart/form-data"
+	ContentTypeXML        = "application/xml"
+)
+
+// PartEncoding documents how one property of a multipart body is sent:
+// its content type (e.g. "application/json" for a metadata part or
+// "image/png, image/jpeg" for a file) and the headers of that part,
+// keyed by name with their description
+type PartEncoding struct {
+	ContentType string
+	Headers     map[string]string
+}
+
+// Body creates a required request body whose content type is detected from
+// the schema (see detectContentType)
+func Body(schema interface{}) *RequestBody {
+	return &RequestBody{
+		Required: true,
+		Schema:   schema,
+	}
+}
+
+// OptionalBody creates a request body clients may omit, e.g. on a PATCH
+// whose empty body is valid
+func OptionalBody(schema interface{}) *RequestBody {
+	return &RequestBody{Schema: schema}
+}
+
+// URLEncodedBody creates a required application/x-www-form-urlencoded request body
+func URLEncodedBody(schema interface{}) *RequestBody {
+	return &RequestBody{
+		Required:    true,
+		Schema:      schema,
+		ContentType: ContentTypeURLEncoded,
+	}
+}
+
+// FormBody creates a required multipart/form-data request body. Use
+// WithPartEncoding to document the content type of individual parts.
+func FormBody(schema interface{}) *RequestBody {
+	return &RequestBody{
+		Required:    true,
+		Schema:      schema,
+		ContentType: ContentTypeMultipart,
+	}
+}
+
+// WithPartEncoding sets the encoding of the property's part
+func (rb *RequestBody) WithPartEncoding(property string, encoding PartEncoding) *RequestBody {
+	if rb.Encoding == nil {
+		rb.Encoding = make(map[string]PartEncoding)
+	}
+	rb.Encoding[property] = encoding
+	return rb
+}
+
+// detectContentType guesses the body content type when none is set explicitly.
+// A struct whose fields all carry a form tag and no json tag is treated as a
+// urlencoded form; everything else defaults to JSON.
+func detectContentType(v interface{}) string {
+	t := reflect.TypeOf(v)
+	if t == nil {
+		return ContentTypeJSON
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return ContentTypeJSON
+	}
+
+	formFields := 0
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if _, ok := field.Tag.Lookup("json"); ok {
+			return ContentTypeJSON
+		}
+		form, ok := field.Tag.Lookup("form")
+		if !ok {
+			return ContentTypeJSON
+		}
+		if form != "-" {
+			formFields++
+		}
+	}
+
+	if formFields == 0 {
+		return ContentTypeJSON
+	}
+	return ContentTypeURLEncoded
+}
+
+// formEncoding describes how array properties are serialized in a urlencoded form
+func formEncoding(s *spec.Schema) map[string]*spec.Encoding {
+	if s == nil {
+		return nil
+	}
+
+	var encoding map[string]*spec.Encoding
+	for name, prop := range s.Properties {
+		if prop != nil && prop.Type == "array" {
+			if encoding == nil {
+				encoding = make(map[string]*spec.Encoding)
+			}
+			encoding[name] = &spec.Encoding{Style: "form", Explode: true}
+		}
+	}
+	return encoding
+}
+
+// partEncoding adds the declared part encodings to the generated ones
+func partEncoding(encoding map[string]*spec.Encoding, parts map[string]PartEncoding) map[string]*spec.Encoding {
+	for property, part := range parts {
+		if encoding == nil {
+			encoding = make(map[string]*spec.Encoding)
+		}
+		enc := encoding[property]
+		if enc == nil {
+			enc = &spec.Encoding{}
+			encoding[property] = enc
+		}
+		enc.ContentType = part.ContentType
+		for name, description := range part.Headers {
+			if enc.Headers == nil {
+				enc.Headers = make(map[string]*spec.Header)
+			}
+			enc.Headers[name] = &spec.Header{Description: description, Schema: spec.NewSchema("string")}
+		}
+	}
+	return encoding
+}
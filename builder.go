@@ -0,0 +1,130 @@
+package openswag
+
+// EndpointBuilder builds an Endpoint with a fluent API
+type EndpointBuilder struct {
+	ep Endpoint
+}
+
+// NewEndpoint starts building an endpoint for the given method and path
+func NewEndpoint(method, path string) *EndpointBuilder {
+	return &EndpointBuilder{ep: Endpoint{Method: method, Path: path}}
+}
+
+// GET starts building a GET endpoint
+func GET(path string) *EndpointBuilder { return NewEndpoint("GET", path) }
+
+// POST starts building a POST endpoint
+func POST(path string) *EndpointBuilder { return NewEndpoint("POST", path) }
+
+// PUT starts building a PUT endpoint
+func PUT(path string) *EndpointBuilder { return NewEndpoint("PUT", path) }
+
+// PATCH starts building a PATCH endpoint
+func PATCH(path string) *EndpointBuilder { return NewEndpoint("PATCH", path) }
+
+// DELETE starts building a DELETE endpoint
+func DELETE(path string) *EndpointBuilder { return NewEndpoint("DELETE", path) }
+
+// HEAD starts building a HEAD endpoint
+func HEAD(path string) *EndpointBuilder { return NewEndpoint("HEAD", path) }
+
+// OPTIONS starts building an OPTIONS endpoint
+func OPTIONS(path string) *EndpointBuilder { return NewEndpoint("OPTIONS", path) }
+
+// OperationID sets the operation ID
+func (b *EndpointBuilder) OperationID(id string) *EndpointBuilder {
+	b.ep.OperationID = id
+	return b
+}
+
+// Summary sets the summary
+func (b *EndpointBuilder) Summary(summary string) *EndpointBuilder {
+	b.ep.Summary = summary
+	return b
+}
+
+// Description sets the description
+func (b *EndpointBuilder) Description(desc string) *EndpointBuilder {
+	b.ep.Description = desc
+	return b
+}
+
+// Tag adds tags
+func (b *EndpointBuilder) Tag(tags ...string) *EndpointBuilder {
+	b.ep.Tags = append(b.ep.Tags, tags...)
+	return b
+}
+
+// Param adds parameters
+func (b *EndpointBuilder) Param(params ...Parameter) *EndpointBuilder {
+	b.ep.Parameters = append(b.ep.Parameters, params...)
+	return b
+}
+
+// QueryParams sets the struct describing the query parameters
+func (b *EndpointBuilder) QueryParams(v interface{}) *EndpointBuilder {
+	b.ep.QueryParams = v
+	return b
+}
+
+// PathParams sets the struct describing the path parameters
+func (b *EndpointBuilder) PathParams(v interface{}) *EndpointBuilder {
+	b.ep.PathParams = v
+	return b
+}
+
+// Body sets a required JSON request body
+func (b *EndpointBuilder) Body(schema interface{}) *EndpointBuilder {
+	b.ep.RequestBody = &RequestBody{Schema: schema, Required: true}
+	return b
+}
+
+// OptionalBody sets a JSON request body clients may omit
+func (b *EndpointBuilder) OptionalBody(schema interface{}) *EndpointBuilder {
+	b.ep.RequestBody = OptionalBody(schema)
+	return b
+}
+
+// RequestBody sets the request body
+func (b *EndpointBuilder) RequestBody(body RequestBody) *EndpointBuilder {
+	b.ep.RequestBody = &body
+	return b
+}
+
+// Response adds a response for a status code
+func (b *EndpointBuilder) Response(code int, description string, schema interface{}) *EndpointBuilder {
+	if b.ep.Responses == nil {
+		b.ep.Responses = make(map[int]Response)
+	}
+	b.ep.Responses[code] = Response{Description: description, Schema: schema}
+	return b
+}
+
+// Security adds security requirements
+func (b *EndpointBuilder) Security(schemes ...string) *EndpointBuilder {
+	b.ep.Security = append(b.ep.Security, schemes...)
+	return b
+}
+
+// Public opts the endpoint out of the global security requirement
+func (b *EndpointBuilder) Public() *EndpointBuilder {
+	b.ep.Public = true
+	return b
+}
+
+// Deprecated marks the endpoint as deprecated
+func (b *EndpointBuilder) Deprecated() *EndpointBuilder {
+	b.ep.Deprecated = true
+	return b
+}
+
+// Sunset marks the endpoint deprecated with a removal date (RFC 1123 or RFC 3339)
+func (b *EndpointBuilder) Sunset(date string) *EndpointBuilder {
+	b.ep.SunsetDate = date
+	return b
+}
+
+// Build returns the endpoint
+func (b *EndpointBuilder) Build() Endpoint {
+	return b.ep
+}
@@ -0,0 +1,34 @@
+package openswag
+
+import (
+	"strings"
+
+	"github.com/andrianprasetya/open-swag-go/pkg/spec"
+)
+
+// Cacheable returns a Cache-Control value for responses shared caches may
+// store for maxAge seconds, e.g. "public, max-age=300"
+func Cacheable(maxAge int) string {
+	return "public, max-age=" + intToString(maxAge)
+}
+
+// applyCacheControl adds the x-cache-control extension and a Cache-Control
+// header to the operation's success responses
+func applyCacheControl(ep Endpoint, op *spec.Operation) {
+	if ep.CacheControl == "" {
+		return
+	}
+
+	op.WithExtension("x-cache-control", ep.CacheControl)
+
+	for code, resp := range op.Responses {
+		if !strings.HasPrefix(code, "2") {
+			continue
+		}
+		resp.AddHeader("Cache-Control", &spec.Header{
+			Description: "Caching directives for this response",
+			Schema:      spec.NewSchema("string"),
+			Example:     ep.CacheControl,
+		})
+	}
+}
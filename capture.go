@@ -0,0 +1,79 @@
+package openswag
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/andrianprasetya/open-swag-go/pkg/spec"
+)
+
+// captureExample runs a GET endpoint's handler against a synthetic request and
+// attaches the recorded JSON body as the example of the matching response.
+// Handlers that panic, return a status that isn't documented, or write
+// non-JSON are skipped, as are responses with documented Examples.
+func captureExample(ep Endpoint, op *spec.Operation) {
+	if ep.Handler == nil || !strings.EqualFold(ep.Method, http.MethodGet) {
+		return
+	}
+
+	rec, ok := invokeHandler(ep.Handler, samplePath(ep.Path))
+	if !ok {
+		return
+	}
+
+	resp := op.Responses[intToString(rec.Code)]
+	if resp == nil {
+		return
+	}
+
+	mediaType, _, err := mime.ParseMediaType(rec.Header().Get("Content-Type"))
+	if err != nil || (mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json")) {
+		return
+	}
+
+	var example interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &example); err != nil {
+		return
+	}
+
+	if resp.Content == nil {
+		resp.Content = make(map[string]*spec.MediaType)
+	}
+	if resp.Content[mediaType] == nil {
+		resp.Content[mediaType] = &spec.MediaType{}
+	}
+	if resp.Content[mediaType].Examples != nil {
+		// example and examples are mutually exclusive; documented ones win
+		return
+	}
+	resp.Content[mediaType].Example = example
+}
+
+// invokeHandler calls handler with a GET request for path, recovering from panics
+func invokeHandler(handler http.HandlerFunc, path string) (rec *httptest.ResponseRecorder, ok bool) {
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+
+	rec = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set("Accept", "application/json")
+	handler(rec, req)
+	return rec, true
+}
+
+// samplePath fills path parameters with placeholder values
+func samplePath(path string) string {
+	parts := strings.Split(path, "/")
+	for i, part := range parts {
+		if strings.HasPrefix(part, ":") || (strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}")) {
+			parts[i] = "1"
+		}
+	}
+	return strings.Join(parts, "/")
+}
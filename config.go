@@ -1,5 +1,11 @@
 package openswag
 
+import (
+	"time"
+
+	"github.com/andrianprasetya/open-swag-go/pkg/schema"
+)
+
 // Config is the main configuration for the documentation
 type Config struct {
 	Info     Info      `json:"info"`
@@ -7,6 +13,68 @@ type Config struct {
 	Tags     []Tag     `json:"tags,omitempty"`
 	UI       UIConfig  `json:"ui"`
 	DocsAuth *DocsAuth `json:"docsAuth,omitempty"`
+	// DynamicServers prepends the URL the spec was requested from (scheme, host
+	// and ServerBasePath) to the served servers
+	DynamicServers bool `json:"dynamicServers,omitempty"`
+	// TrustForwardedHeaders makes DynamicServers take the scheme and host from
+	// X-Forwarded-Proto/Host. Only enable it behind a proxy that overwrites
+	// these headers, as clients can otherwise choose the served server URL.
+	TrustForwardedHeaders bool `json:"trustForwardedHeaders,omitempty"`
+	// ServerBasePath is appended to the request-derived server URL (e.g. "/api/v1")
+	ServerBasePath string `json:"serverBasePath,omitempty"`
+	// OpenAPIVersion is the version written to the spec (default "3.1.0").
+	// A 3.0.x version emits "nullable": true instead of ["type", "null"] arrays.
+	// Versions outside spec.SupportedVersions are rejected by New and NewE.
+	OpenAPIVersion string `json:"openapiVersion,omitempty"`
+	// DefaultLanguage is used for translations when no ?lang= is requested
+	// and as the fallback for keys missing in the requested language
+	DefaultLanguage string `json:"defaultLanguage,omitempty"`
+	// CaptureExamples invokes the Handler of GET endpoints while building the
+	// spec and uses the recorded JSON body as the response example
+	CaptureExamples bool `json:"captureExamples,omitempty"`
+	// Security is the global security requirement applied to every endpoint
+	// that neither sets its own Security nor is marked Public
+	Security []string `json:"security,omitempty"`
+	// TagSecurity applies a default security requirement to every endpoint
+	// carrying the tag, unless the endpoint sets its own Security
+	TagSecurity map[string][]string `json:"tagSecurity,omitempty"`
+	// ResponseDescriptions overrides the description used for responses that
+	// leave it empty (defaults to http.StatusText, e.g. 404 -> "Not Found")
+	ResponseDescriptions map[int]string `json:"responseDescriptions,omitempty"`
+	// PrettyPrint indents the served spec (default true). Set it to false to
+	// serve compact JSON in production; ?pretty=true still returns the indented form
+	PrettyPrint *bool `json:"prettyPrint,omitempty"`
+	// SpecCacheSize bounds how many served spec variants (per tags, lang,
+	// pretty and server URL) are kept marshalled; 0 means
+	// DefaultSpecCacheSize and a negative value disables the cache
+	SpecCacheSize int `json:"specCacheSize,omitempty"`
+	// IncludeInternal makes BuildSpec and SpecHandler include endpoints marked Internal
+	IncludeInternal bool `json:"includeInternal,omitempty"`
+	// OperationIDCollisions chooses between auto-suffixing duplicate derived
+	// operationIds (OperationIDAutoSuffix, default) and reporting them from
+	// Validate (OperationIDError)
+	OperationIDCollisions OperationIDCollisionMode `json:"operationIdCollisions,omitempty"`
+	// FieldNaming names struct fields that have no json tag: "asis" (default),
+	// "camel" (UserID -> userID) or "snake" (UserID -> user_id)
+	FieldNaming schema.FieldNaming `json:"fieldNaming,omitempty"`
+	// PreserveFieldOrder emits each struct schema's declaration order of
+	// fields as x-field-order, for UIs that render source order instead of
+	// alphabetical order
+	PreserveFieldOrder bool `json:"preserveFieldOrder,omitempty"`
+	// MaxSchemaDepth stops expanding nested structs past this many levels,
+	// documenting deeper ones as {"type": "object"}; 0 means unlimited
+	MaxSchemaDepth int `json:"maxSchemaDepth,omitempty"`
+	// StrictValidation makes Validate also check the structure of the full
+	// spec against a subset of the OpenAPI 3.1 meta-schema (see
+	// Docs.ValidateAgainstMetaSchema); it is skipped for OpenAPI 3.0 documents
+	StrictValidation bool `json:"strictValidation,omitempty"`
+	// ResponseWrapper wraps the JSON schema of every 2xx response in the API's
+	// envelope (see Envelope); endpoints with RawResponses are left as-is
+	ResponseWrapper SchemaWrapper `json:"-"`
+	// ErrorWrapper does the same for 4xx and 5xx responses
+	ErrorWrapper SchemaWrapper `json:"-"`
+	// Logger receives spec rebuild, auth failure and proxy events (no-op by default)
+	Logger Logger `json:"-"`
 }
 
 // Predefined security scheme names for use in Endpoint.Security
@@ -26,6 +94,11 @@ type DocsAuth struct {
 	Realm    string `json:"realm,omitempty"`
 	// Alternative: use API key in query param (?key=xxx)
 	APIKey string `json:"apiKey,omitempty"`
+	// SessionSecret enables a signed session cookie after a successful login,
+	// so credentials don't have to be re-sent on every request
+	SessionSecret string `json:"sessionSecret,omitempty"`
+	// SessionTTL is how long the session cookie stays valid (default 1h)
+	SessionTTL time.Duration `json:"sessionTtl,omitempty"`
 }
 
 // Info represents OpenAPI info object
@@ -33,6 +106,7 @@ type Info struct {
 	Title          string   `json:"title"`
 	Version        string   `json:"version"`
 	Description    string   `json:"description,omitempty"`
+	Summary        string   `json:"summary,omitempty"` // OpenAPI 3.1; dropped from 3.0 documents
 	TermsOfService string   `json:"termsOfService,omitempty"`
 	Contact        *Contact `json:"contact,omitempty"`
 	License        *License `json:"license,omitempty"`
@@ -49,6 +123,9 @@ type Contact struct {
 type License struct {
 	Name string `json:"name"`
 	URL  string `json:"url,omitempty"`
+	// Identifier is an SPDX license expression (e.g. "Apache-2.0"), an
+	// OpenAPI 3.1 alternative to URL; it is dropped from 3.0 documents
+	Identifier string `json:"identifier,omitempty"`
 }
 
 // Server represents an API server
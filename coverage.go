@@ -0,0 +1,132 @@
+package openswag
+
+import (
+	"sort"
+	"strings"
+)
+
+// Route is a registered method and path, e.g. {"GET", "/users/{id}"}.
+// An empty Method matches any method.
+type Route struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+}
+
+// CoverageReport compares documented endpoints against registered routes
+type CoverageReport struct {
+	// Covered lists routes that are documented
+	Covered []Route `json:"covered"`
+	// Undocumented lists registered routes without documentation
+	Undocumented []Route `json:"undocumented"`
+	// Orphaned lists documented endpoints without a registered route
+	Orphaned []Route `json:"orphaned"`
+}
+
+// IsComplete reports whether every route is documented and every documented endpoint is routed
+func (c CoverageReport) IsComplete() bool {
+	return len(c.Undocumented) == 0 && len(c.Orphaned) == 0
+}
+
+// RoutesFromPatterns parses Go 1.22 http.ServeMux patterns such as
+// "GET /users/{id}" or "/health" into routes. Host prefixes are ignored.
+func RoutesFromPatterns(patterns ...string) []Route {
+	routes := make([]Route, 0, len(patterns))
+	for _, pattern := range patterns {
+		var route Route
+		pattern = strings.TrimSpace(pattern)
+		if method, rest, ok := strings.Cut(pattern, " "); ok {
+			route.Method = strings.ToUpper(method)
+			pattern = strings.TrimSpace(rest)
+		}
+		if i := strings.Index(pattern, "/"); i > 0 {
+			pattern = pattern[i:] // drop host
+		}
+		route.Path = pattern
+		routes = append(routes, route)
+	}
+	return routes
+}
+
+// Coverage reports which routes are undocumented and which documented
+// endpoints have no route. Results are sorted by path, then method.
+func (d *Docs) Coverage(routes []Route) CoverageReport {
+	d.mu.RLock()
+	documented := make([]Route, 0, len(d.endpoints))
+	for _, ep := range d.endpoints {
+		documented = append(documented, Route{Method: strings.ToUpper(ep.Method), Path: ep.Path})
+	}
+	d.mu.RUnlock()
+
+	report := CoverageReport{
+		Covered:      []Route{},
+		Undocumented: []Route{},
+		Orphaned:     []Route{},
+	}
+
+	for _, route := range routes {
+		matched := false
+		for _, doc := range documented {
+			if routeMatches(route, doc) {
+				matched = true
+				break
+			}
+		}
+		if matched {
+			report.Covered = append(report.Covered, route)
+		} else {
+			report.Undocumented = append(report.Undocumented, route)
+		}
+	}
+
+	for _, doc := range documented {
+		routed := false
+		for _, route := range routes {
+			if routeMatches(route, doc) {
+				routed = true
+				break
+			}
+		}
+		if !routed {
+			report.Orphaned = append(report.Orphaned, doc)
+		}
+	}
+
+	sortRoutes(report.Covered)
+	sortRoutes(report.Undocumented)
+	sortRoutes(report.Orphaned)
+	return report
+}
+
+// routeMatches reports whether a registered route serves a documented endpoint
+func routeMatches(route, doc Route) bool {
+	if route.Method != "" && !strings.EqualFold(route.Method, doc.Method) {
+		return false
+	}
+	return normalizeRoutePath(route.Path) == normalizeRoutePath(doc.Path)
+}
+
+// normalizeRoutePath replaces every path parameter with "{}" so that
+// /users/:id, /users/{id} and /users/{userID} compare equal
+func normalizeRoutePath(path string) string {
+	path = strings.TrimSuffix(path, "{$}")
+	if len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+
+	parts := strings.Split(path, "/")
+	for i, part := range parts {
+		if _, ok := pathParamName(part); ok {
+			parts[i] = "{}"
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+func sortRoutes(routes []Route) {
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+}
@@ -0,0 +1,36 @@
+package openswag
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCoverage(t *testing.T) {
+	docs := New(Config{Info: Info{Title: "Test", Version: "1.0.0"}})
+	docs.AddAll(
+		Endpoint{Method: "GET", Path: "/users"},
+		Endpoint{Method: "GET", Path: "/users/:id"},
+		Endpoint{Method: "DELETE", Path: "/users/{id}"},
+	)
+
+	report := docs.Coverage(RoutesFromPatterns(
+		"GET /users",
+		"GET /users/{userID}",
+		"POST /users",
+		"/health",
+	))
+
+	expectedUndocumented := []Route{{Path: "/health"}, {Method: "POST", Path: "/users"}}
+	if !reflect.DeepEqual(report.Undocumented, expectedUndocumented) {
+		t.Errorf("expected undocumented %v, got %v", expectedUndocumented, report.Undocumented)
+	}
+
+	expectedOrphaned := []Route{{Method: "DELETE", Path: "/users/{id}"}}
+	if !reflect.DeepEqual(report.Orphaned, expectedOrphaned) {
+		t.Errorf("expected orphaned %v, got %v", expectedOrphaned, report.Orphaned)
+	}
+
+	if report.IsComplete() {
+		t.Error("expected incomplete coverage")
+	}
+}
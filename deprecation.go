@@ -0,0 +1,80 @@
+package openswag
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/andrianprasetya/open-swag-go/pkg/spec"
+)
+
+// Deprecation describes why and when an endpoint is retired
+type Deprecation struct {
+	Reason string
+	// Sunset is the removal date in RFC 1123 or RFC 3339 format
+	Sunset string
+}
+
+// Deprecated creates a deprecation notice for Endpoint.Deprecation
+func Deprecated(reason, sunset string) *Deprecation {
+	return &Deprecation{Reason: reason, Sunset: sunset}
+}
+
+// ValidateSunsetDate checks that a sunset date is in RFC 1123 or RFC 3339 format
+func ValidateSunsetDate(date string) error {
+	if _, err := time.Parse(time.RFC1123, date); err == nil {
+		return nil
+	}
+	if _, err := time.Parse(time.RFC3339, date); err == nil {
+		return nil
+	}
+	return fmt.Errorf("invalid sunset date %q: expected RFC 1123 or RFC 3339", date)
+}
+
+// sunsetDate returns the effective sunset date of an endpoint
+func sunsetDate(ep Endpoint) string {
+	if ep.SunsetDate != "" {
+		return ep.SunsetDate
+	}
+	if ep.Deprecation != nil {
+		return ep.Deprecation.Sunset
+	}
+	return ""
+}
+
+// applyDeprecation marks the operation deprecated and documents the
+// Deprecation and Sunset headers when the endpoint is being retired
+func (d *Docs) applyDeprecation(ep Endpoint, op *spec.Operation) {
+	sunset := sunsetDate(ep)
+	if sunset == "" && ep.Deprecation == nil {
+		return
+	}
+
+	op.SetDeprecated(true)
+
+	if ep.Deprecation != nil && ep.Deprecation.Reason != "" {
+		op.WithExtension("x-deprecation-reason", ep.Deprecation.Reason)
+	}
+
+	if sunset != "" {
+		if err := ValidateSunsetDate(sunset); err != nil {
+			d.config.Logger.Warn("openswag: ignoring sunset date", "method", ep.Method, "path", ep.Path, "error", err)
+			sunset = ""
+		} else {
+			op.WithExtension("x-sunset", sunset)
+		}
+	}
+
+	for _, resp := range op.Responses {
+		resp.AddHeader("Deprecation", &spec.Header{
+			Description: "Indicates that this endpoint is deprecated",
+			Schema:      spec.NewSchema("string"),
+		})
+		if sunset != "" {
+			resp.AddHeader("Sunset", &spec.Header{
+				Description: "Date after which this endpoint will be removed",
+				Schema:      spec.NewSchema("string"),
+				Example:     sunset,
+			})
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package openswag
+
+import "github.com/andrianprasetya/open-swag-go/pkg/spec"
+
+// AcceptEncodingParam creates the optional Accept-Encoding header parameter
+// limited to the supported content codings, "gzip", "deflate" and "br" by
+// default
+func AcceptEncodingParam(encodings ...string) Parameter {
+	return HeaderParam("Accept-Encoding", "Content codings the client accepts for the response").
+		WithSchema(encodingSchema(encodings))
+}
+
+// ContentEncodingHeader documents the Content-Encoding response header with
+// the codings the server may apply, the same defaults as AcceptEncodingParam:
+//
+//	Headers: map[string]openswag.ResponseHeader{"Content-Encoding": openswag.ContentEncodingHeader("gzip", "br")}
+func ContentEncodingHeader(encodings ...string) ResponseHeader {
+	return ResponseHeader{
+		Description: "Content coding applied to the response body",
+		Schema:      encodingSchema(encodings),
+	}
+}
+
+func encodingSchema(encodings []string) *spec.Schema {
+	if len(encodings) == 0 {
+		encodings = []string{"gzip", "deflate", "br"}
+	}
+	values := make([]any, len(encodings))
+	for i, encoding := range encodings {
+		values[i] = encoding
+	}
+	return &spec.Schema{Type: "string", Enum: values}
+}
@@ -0,0 +1,52 @@
+package openswag
+
+import "github.com/andrianprasetya/open-swag-go/pkg/spec"
+
+// Example is a named request or response example. Plain values in an
+// Examples map are inlined as-is; use Example to add a summary or to point at
+// a body hosted elsewhere.
+type Example struct {
+	Summary     string
+	Description string
+	Value       interface{}
+	// ExternalValue is the URL of the full example body; it takes precedence over Value
+	ExternalValue string
+}
+
+// ExternalExample references an example body hosted at url, keeping large
+// payloads out of the spec
+func ExternalExample(url string) Example {
+	return Example{ExternalValue: url}
+}
+
+// buildExamples converts an Examples map into OpenAPI example objects
+func buildExamples(examples map[string]interface{}) map[string]*spec.Example {
+	if len(examples) == 0 {
+		return nil
+	}
+
+	result := make(map[string]*spec.Example, len(examples))
+	for name, v := range examples {
+		ex, ok := v.(Example)
+		if p, isPtr := v.(*Example); isPtr && p != nil {
+			ex, ok = *p, true
+		}
+		if !ok {
+			result[name] = &spec.Example{Value: v}
+			continue
+		}
+
+		e := &spec.Example{Summary: ex.Summary, Description: ex.Description}
+		if ex.ExternalValue != "" {
+			e.ExternalValue = ex.ExternalValue
+			if e.Description == "" {
+				// Not every UI fetches external examples; leave a link to follow
+				e.Description = "[Full example](" + ex.ExternalValue + ")"
+			}
+		} else {
+			e.Value = ex.Value
+		}
+		result[name] = e
+	}
+	return result
+}
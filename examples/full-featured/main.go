@@ -2,12 +2,34 @@ package main
 
 import (
 	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
 
 	openswag "github.com/andrianprasetya/open-swag-go"
+	"github.com/andrianprasetya/open-swag-go/pkg/schema"
 )
 
+// Money is a value object serialized as a formatted string ("19.99 USD")
+type Money struct {
+	Cents    int64
+	Currency string
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(fmt.Sprintf("%d.%02d %s", m.Cents/100, m.Cents%100, m.Currency))
+}
+
+// OpenAPISchema documents Money as the string it marshals to instead of its fields
+func (Money) OpenAPISchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        "string",
+		Pattern:     `^\d+\.\d{2} [A-Z]{3}$`,
+		Description: "Amount and ISO 4217 currency code",
+		Example:     "19.99 USD",
+	}
+}
+
 // DTOs
 type CreateProductRequest struct {
 	Name        string  `json:"name"`
@@ -17,12 +39,12 @@ type CreateProductRequest struct {
 }
 
 type ProductResponse struct {
-	ID          string  `json:"id"`
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
-	CategoryID  string  `json:"category_id"`
-	CreatedAt   string  `json:"created_at"`
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Price       Money  `json:"price"`
+	CategoryID  string `json:"category_id"`
+	CreatedAt   string `json:"created_at"`
 }
 
 type PaginatedProducts struct {
@@ -76,6 +98,11 @@ func deleteProduct(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func downloadProductSheet(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", `attachment; filename="product.pdf"`)
+}
+
 // Endpoint definitions - use predefined security constants
 var CreateProductDoc = openswag.Endpoint{
 	Method:      "POST",
@@ -150,13 +177,27 @@ var DeleteProductDoc = openswag.Endpoint{
 	Tags:        []string{"Products"},
 	PathParams:  ProductPathParams{},
 	Responses: map[int]openswag.Response{
-		204: {Description: "Product deleted"},
+		204: openswag.NoContentResponse("Product deleted"),
 		404: {Description: "Product not found", Schema: ErrorResponse{}},
 	},
 	Security:   []string{openswag.SecurityBearerAuth},
 	Deprecated: false,
 }
 
+var DownloadProductSheetDoc = openswag.Endpoint{
+	Method:      "GET",
+	Path:        "/products/{id}/sheet",
+	Summary:     "Download product sheet",
+	Description: "Download the product specification sheet as a PDF",
+	Tags:        []string{"Products"},
+	PathParams:  ProductPathParams{},
+	Responses: map[int]openswag.Response{
+		200: openswag.FileDownloadResponse("Product sheet", "application/pdf"),
+		404: {Description: "Product not found", Schema: ErrorResponse{}},
+	},
+	Security: []string{openswag.SecurityBearerAuth},
+}
+
 func main() {
 	docs := openswag.New(openswag.Config{
 		Info: openswag.Info{
@@ -204,6 +245,7 @@ func main() {
 		ListProductsDoc,
 		UpdateProductDoc,
 		DeleteProductDoc,
+		DownloadProductSheetDoc,
 	)
 
 	mux := http.NewServeMux()
@@ -214,6 +256,7 @@ func main() {
 	mux.HandleFunc("GET /products/{id}", getProduct)
 	mux.HandleFunc("PUT /products/{id}", updateProduct)
 	mux.HandleFunc("DELETE /products/{id}", deleteProduct)
+	mux.HandleFunc("GET /products/{id}/sheet", downloadProductSheet)
 
 	log.Println("Server running on http://localhost:8080")
 	log.Println("Docs at http://localhost:8080/docs/")
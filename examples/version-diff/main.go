@@ -12,7 +12,7 @@ import (
 func main() {
 	// Example: Compare two OpenAPI spec files
 	if len(os.Args) < 3 {
-		fmt.Println("Usage: version-diff <old-spec.json> <new-spec.json>")
+		fmt.Println("Usage: version-diff <old-spec.json> <new-spec.json> [text|json|markdown|junit]")
 		fmt.Println("\nThis tool compares two OpenAPI specifications and detects:")
 		fmt.Println("  - Added endpoints")
 		fmt.Println("  - Removed endpoints (breaking)")
@@ -34,6 +34,16 @@ func main() {
 		log.Fatalf("Error comparing specs: %v", err)
 	}
 
+	if len(os.Args) > 3 {
+		// e.g. junit for CI, so breaking changes show up as failed tests
+		out, err := versioning.FormatDiff(diff, os.Args[3])
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(out)
+		return
+	}
+
 	printDiff(diff)
 }
 
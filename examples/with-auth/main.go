@@ -114,7 +114,10 @@ func main() {
 	bearerScheme := auth.BearerAuth("JWT authentication")
 	apiKeyScheme := auth.APIKeyHeader("X-API-Key", "API key authentication")
 
-	openapi := docs.BuildSpec()
+	openapi, err := docs.BuildSpecE()
+	if err != nil {
+		log.Fatalf("build spec: %v", err)
+	}
 	openapi.AddSecurityScheme("bearerAuth", &spec.SecurityScheme{
 		Type:         "http",
 		Scheme:       "bearer",
@@ -0,0 +1,247 @@
+package openswag
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/andrianprasetya/open-swag-go/pkg/examples"
+	"github.com/andrianprasetya/open-swag-go/pkg/spec"
+)
+
+// exportedRequest is an operation prepared for the request collection
+// exporters (Postman, Insomnia), which only differ in how they render it
+type exportedRequest struct {
+	Folder      string
+	Name        string
+	Method      string
+	Description string
+	// Server is the operation's own server URL; empty means the base URL variable
+	Server string
+	// Path holds the path segments, with parameters written as :name
+	Path        []string
+	PathParams  []exportedParam
+	Query       []exportedParam
+	Headers     []exportedParam
+	ContentType string
+	// Body is the example request body, nil when the operation has none
+	Body interface{}
+	// Auth is nil when the request uses the collection-wide auth
+	Auth *exportedAuth
+}
+
+type exportedParam struct {
+	Name        string
+	Value       string
+	Description string
+	Required    bool
+}
+
+// exportedAuth is a security scheme reduced to what request tools support:
+// Type is "bearer", "basic", "apikey", "oauth2" or "none"; Name and In
+// locate an API key
+type exportedAuth struct {
+	Type string
+	Name string
+	In   string
+}
+
+// exportRequests prepares every public operation in registration order
+func (d *Docs) exportRequests() (*spec.OpenAPI, []exportedRequest, error) {
+	openapi, err := d.buildSpec(d.config.IncludeInternal)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d.mu.RLock()
+	endpoints := append([]Endpoint(nil), d.endpoints...)
+	d.mu.RUnlock()
+
+	var requests []exportedRequest
+	for _, ep := range endpoints {
+		item := openapi.Paths[openAPIPath(ep.Path)]
+		if item == nil {
+			continue
+		}
+		op := item.Operation(strings.ToUpper(ep.Method))
+		if op == nil {
+			continue
+		}
+		requests = append(requests, d.exportRequest(openapi, ep, op))
+	}
+	return openapi, requests, nil
+}
+
+// exportRequest converts one operation, using the endpoint for its body type
+func (d *Docs) exportRequest(openapi *spec.OpenAPI, ep Endpoint, op *spec.Operation) exportedRequest {
+	req := exportedRequest{
+		Name:        op.Summary,
+		Method:      strings.ToUpper(ep.Method),
+		Description: op.Description,
+	}
+	if req.Name == "" {
+		req.Name = req.Method + " " + openAPIPath(ep.Path)
+	}
+	if len(op.Tags) > 0 {
+		req.Folder = op.Tags[0]
+	}
+	if len(op.Servers) > 0 {
+		req.Server = strings.TrimSuffix(op.Servers[0].URL, "/")
+	}
+
+	for _, segment := range strings.Split(strings.Trim(openAPIPath(ep.Path), "/"), "/") {
+		if segment == "" {
+			continue
+		}
+		if name, ok := pathParamName(segment); ok {
+			segment = ":" + name
+		}
+		req.Path = append(req.Path, segment)
+	}
+
+	for _, p := range op.Parameters {
+		param := exportedParam{
+			Name:        p.Name,
+			Value:       exportValue(parameterDefault(p)),
+			Description: p.Description,
+			Required:    p.Required,
+		}
+		switch p.In {
+		case "path":
+			req.PathParams = append(req.PathParams, param)
+		case "query":
+			req.Query = append(req.Query, param)
+		case "header":
+			req.Headers = append(req.Headers, param)
+		}
+	}
+
+	if ep.RequestBody != nil {
+		req.ContentType = ep.RequestBody.ContentType
+		if req.ContentType == "" {
+			req.ContentType = detectContentType(ep.RequestBody.Schema)
+		}
+		req.Body = d.exampleBody(ep.RequestBody, op.RequestBody, req.ContentType)
+	}
+
+	if op.Security != nil {
+		req.Auth = exportAuth(openapi, op.Security)
+		if req.Auth == nil {
+			req.Auth = &exportedAuth{Type: "none"}
+		}
+	}
+	return req
+}
+
+// exampleBody prefers the documented body example and otherwise generates
+// one from the body type
+func (d *Docs) exampleBody(body *RequestBody, rb *spec.RequestBody, contentType string) interface{} {
+	if rb != nil {
+		if media := rb.Content[contentType]; media != nil {
+			if media.Example != nil {
+				return media.Example
+			}
+			if len(media.Examples) > 0 {
+				names := make([]string, 0, len(media.Examples))
+				for name := range media.Examples {
+					names = append(names, name)
+				}
+				sort.Strings(names)
+				if value := media.Examples[names[0]].Value; value != nil {
+					return value
+				}
+			}
+		}
+	}
+	if body.Schema == nil {
+		return map[string]interface{}{}
+	}
+	gen := examples.New(examples.Config{FieldNaming: d.config.FieldNaming})
+	return gen.GenerateFor(body.Schema, examples.ContextRequest)
+}
+
+// exportAuth maps the first requirement's first scheme, nil when there is none
+func exportAuth(openapi *spec.OpenAPI, requirements []spec.SecurityRequirement) *exportedAuth {
+	if len(requirements) == 0 || openapi.Components == nil {
+		return nil
+	}
+	names := make([]string, 0, len(requirements[0]))
+	for name := range requirements[0] {
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	sort.Strings(names)
+	scheme := openapi.Components.SecuritySchemes[names[0]]
+	if scheme == nil {
+		return nil
+	}
+
+	switch {
+	case scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "bearer"):
+		return &exportedAuth{Type: "bearer"}
+	case scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "basic"):
+		return &exportedAuth{Type: "basic"}
+	case scheme.Type == "apiKey":
+		return &exportedAuth{Type: "apikey", Name: scheme.Name, In: scheme.In}
+	case scheme.Type == "oauth2":
+		return &exportedAuth{Type: "oauth2"}
+	}
+	return nil
+}
+
+// exportFields flattens an object body into sorted form fields
+func exportFields(body interface{}) []exportedParam {
+	obj, ok := body.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	names := make([]string, 0, len(obj))
+	for name := range obj {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fields := make([]exportedParam, len(names))
+	for i, name := range names {
+		fields[i] = exportedParam{Name: name, Value: exportValue(obj[name])}
+	}
+	return fields
+}
+
+// exportJSON renders a JSON body indented, falling back to an empty object
+func exportJSON(body interface{}) string {
+	raw, err := json.MarshalIndent(body, "", "  ")
+	if err != nil || body == nil {
+		return "{}"
+	}
+	return string(raw)
+}
+
+// exportValue renders a parameter or form value as text
+func exportValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case map[string]interface{}, []interface{}:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return ""
+		}
+		return string(data)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// exportBaseURL is the first configured server, the value of the baseUrl variable
+func (d *Docs) exportBaseURL() string {
+	if len(d.config.Servers) == 0 {
+		return ""
+	}
+	return strings.TrimSuffix(d.config.Servers[0].URL, "/")
+}
@@ -4,8 +4,12 @@ import (
 	"crypto/subtle"
 	"encoding/json"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/andrianprasetya/open-swag-go/pkg/tryit"
 	"github.com/andrianprasetya/open-swag-go/pkg/ui"
 )
 
@@ -17,10 +21,17 @@ func (d *Docs) basicAuth(next http.HandlerFunc) http.HandlerFunc {
 			return
 		}
 
+		// Option 0: Session cookie from an earlier successful login
+		if d.validSession(r) {
+			next(w, r)
+			return
+		}
+
 		// Option 1: API Key in query param (?key=xxx)
 		if d.config.DocsAuth.APIKey != "" {
 			key := r.URL.Query().Get("key")
 			if subtle.ConstantTimeCompare([]byte(key), []byte(d.config.DocsAuth.APIKey)) == 1 {
+				d.grantSession(w, r)
 				next(w, r)
 				return
 			}
@@ -33,16 +44,41 @@ func (d *Docs) basicAuth(next http.HandlerFunc) http.HandlerFunc {
 				usernameMatch := subtle.ConstantTimeCompare([]byte(username), []byte(d.config.DocsAuth.Username)) == 1
 				passwordMatch := subtle.ConstantTimeCompare([]byte(password), []byte(d.config.DocsAuth.Password)) == 1
 				if usernameMatch && passwordMatch {
+					d.grantSession(w, r)
 					next(w, r)
 					return
 				}
 			}
 		}
 
+		d.metrics.authFailures.Add(1)
+		d.config.Logger.Warn("openswag: docs auth failed", "path", r.URL.Path, "remote", r.RemoteAddr)
 		d.unauthorized(w)
 	}
 }
 
+// withoutDocsCredentials returns a copy of the request without the docs
+// session cookie and, when it holds the docs login, the Authorization header.
+// An Authorization header meant for the target API is kept.
+func (d *Docs) withoutDocsCredentials(r *http.Request) *http.Request {
+	r = r.Clone(r.Context())
+
+	if auth := d.config.DocsAuth; auth != nil && auth.Username != "" {
+		if username, password, ok := r.BasicAuth(); ok && username == auth.Username && password == auth.Password {
+			r.Header.Del("Authorization")
+		}
+	}
+
+	cookies := r.Cookies()
+	r.Header.Del("Cookie")
+	for _, cookie := range cookies {
+		if cookie.Name != SessionCookieName {
+			r.AddCookie(cookie)
+		}
+	}
+	return r
+}
+
 func (d *Docs) unauthorized(w http.ResponseWriter) {
 	realm := d.config.DocsAuth.Realm
 	if realm == "" {
@@ -55,9 +91,16 @@ func (d *Docs) unauthorized(w http.ResponseWriter) {
 	http.Error(w, "Unauthorized", http.StatusUnauthorized)
 }
 
-// Handler returns the documentation UI handler
+// Handler returns the documentation UI handler.
+// Clients that prefer JSON over HTML in their Accept header get the spec instead.
 func (d *Docs) Handler() http.HandlerFunc {
 	return d.basicAuth(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept")
+		if prefersJSON(r.Header.Get("Accept")) {
+			d.serveSpec(w, r)
+			return
+		}
+
 		config := ui.ScalarConfig{
 			Theme:       d.config.UI.Theme,
 			Layout:      d.config.UI.Layout,
@@ -66,7 +109,12 @@ func (d *Docs) Handler() http.HandlerFunc {
 			CustomCSS:   d.config.UI.CustomCSS,
 		}
 
-		scalar := ui.NewScalar("./openapi.json", d.config.Info.Title, config)
+		specURL := "./openapi.json"
+		if lang := r.URL.Query().Get("lang"); lang != "" {
+			specURL += "?lang=" + url.QueryEscape(lang)
+		}
+
+		scalar := ui.NewScalar(specURL, d.config.Info.Title, config)
 		html, err := scalar.Render()
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -75,24 +123,169 @@ func (d *Docs) Handler() http.HandlerFunc {
 
 		w.Header().Set("Content-Type", "text/html; charset=utf-8")
 		w.Write([]byte(html))
+		d.metrics.pageViews.Add(1)
+		d.config.Logger.Debug("openswag: docs page served", "path", r.URL.Path)
 	})
 }
 
-// SpecHandler returns the OpenAPI spec JSON handler
+// SpecHandler returns the OpenAPI spec JSON handler.
+// A ?tags=Users,Auth query returns only the operations with those tags.
 func (d *Docs) SpecHandler() http.HandlerFunc {
+	return d.basicAuth(d.serveSpec)
+}
+
+// InternalSpecHandler returns a handler for the full spec including internal
+// endpoints. It is protected by the docs auth like every other handler.
+func (d *Docs) InternalSpecHandler() http.HandlerFunc {
 	return d.basicAuth(func(w http.ResponseWriter, r *http.Request) {
-		specJSON, err := d.SpecJSON()
+		d.writeSpec(w, r, true)
+	})
+}
+
+func (d *Docs) serveSpec(w http.ResponseWriter, r *http.Request) {
+	d.writeSpec(w, r, d.config.IncludeInternal)
+}
+
+// writeSpec serves the public or internal spec, reusing the marshalled
+// variant for the same tags, language, pretty printing and server URL
+func (d *Docs) writeSpec(w http.ResponseWriter, r *http.Request, internal bool) {
+	query := r.URL.Query()
+	tags, lang := query.Get("tags"), query.Get("lang")
+
+	pretty := d.prettyPrint()
+	if value := query.Get("pretty"); value != "" {
+		if parsed, err := strconv.ParseBool(value); err == nil {
+			pretty = parsed
+		}
+	}
+
+	serverURL := ""
+	if d.config.DynamicServers {
+		serverURL = d.requestServerURL(r)
+	}
+
+	key := strings.Join([]string{strconv.FormatBool(internal), tags, lang, strconv.FormatBool(pretty), serverURL}, "\x00")
+	entry, generation := d.specCache.get(key)
+	if entry == nil {
+		openapi, err := d.buildSpec(internal)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 
-		w.Header().Set("Content-Type", "application/json")
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Write(specJSON)
+		// ?tags=Users,Auth narrows the spec to the operations of those tags
+		if tags != "" {
+			openapi = openapi.FilterByTags(strings.Split(tags, ",")...)
+		}
+
+		// Behind a proxy the configured servers may not be reachable by the client
+		if serverURL != "" {
+			openapi = withServer(openapi, serverURL)
+		}
+
+		specJSON, err := d.specJSON(openapi, lang, pretty)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		entry = d.specCache.put(key, specJSON, generation)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("ETag", entry.etag)
+	d.metrics.specFetches.Add(1)
+	if match := r.Header.Get("If-None-Match"); match != "" && match == entry.etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Write(entry.body)
+	d.config.Logger.Debug("openswag: spec served", "path", r.URL.Path, "lang", lang)
+}
+
+// ProxyHandler returns the Try-It proxy handler, protected by the docs auth.
+// It only forwards to the configured servers, the servers of endpoints that
+// override them, the console's default server and
+// ConsoleConfig.AllowedTargets; relative server URLs must be listed in
+// AllowedTargets. The docs credentials (its basic auth and session cookie)
+// are not forwarded.
+func (d *Docs) ProxyHandler(console tryit.ConsoleConfig) http.HandlerFunc {
+	proxy := tryit.NewProxy(console).
+		WithTimeouts(d.endpointTimeout).
+		WithAllowFunc(d.isEndpointServer)
+	proxy.AllowTargets(console.DefaultServer)
+	for _, srv := range d.config.Servers {
+		proxy.AllowTargets(srv.URL)
+	}
+	return d.basicAuth(func(w http.ResponseWriter, r *http.Request) {
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		start := time.Now()
+		proxy.ServeHTTP(rec, d.withoutDocsCredentials(r))
+		d.metrics.recordProxy(rec.status)
+		d.config.Logger.Info("openswag: proxy request",
+			"method", r.Method,
+			"target", r.URL.Query().Get("url"),
+			"status", rec.status,
+			"duration", time.Since(start),
+		)
 	})
 }
 
+// statusRecorder captures the status code written by a handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.status = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer (e.g. to flush streams)
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// prefersJSON reports whether an Accept header ranks JSON above HTML
+func prefersJSON(accept string) bool {
+	if accept == "" {
+		return false
+	}
+
+	jsonQ, htmlQ := -1.0, -1.0
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, q := parseAcceptPart(part)
+		switch {
+		case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+			jsonQ = max(jsonQ, q)
+		case mediaType == "text/html":
+			htmlQ = max(htmlQ, q)
+		case mediaType == "*/*" || mediaType == "text/*":
+			// Wildcards count towards HTML so browsers keep getting the UI
+			htmlQ = max(htmlQ, q)
+		}
+	}
+
+	return jsonQ > 0 && jsonQ > htmlQ
+}
+
+// parseAcceptPart splits a single Accept entry into its media type and q value
+func parseAcceptPart(part string) (string, float64) {
+	params := strings.Split(part, ";")
+	mediaType := strings.ToLower(strings.TrimSpace(params[0]))
+	q := 1.0
+	for _, param := range params[1:] {
+		key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+		if ok && strings.TrimSpace(key) == "q" {
+			if parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+				q = parsed
+			}
+		}
+	}
+	return mediaType, q
+}
+
 // Mount registers both handlers on a mux
 func (d *Docs) Mount(mux *http.ServeMux, basePath string) {
 	if !strings.HasSuffix(basePath, "/") {
@@ -101,6 +294,11 @@ func (d *Docs) Mount(mux *http.ServeMux, basePath string) {
 
 	mux.HandleFunc(basePath, d.Handler())
 	mux.HandleFunc(basePath+"openapi.json", d.SpecHandler())
+
+	// The full spec is only exposed when the docs are password protected
+	if d.config.DocsAuth != nil && d.config.DocsAuth.Enabled {
+		mux.HandleFunc(basePath+"openapi.internal.json", d.InternalSpecHandler())
+	}
 }
 
 // GetUIConfig returns the UI configuration as JSON for client-side use
@@ -110,6 +308,8 @@ func (d *Docs) GetUIConfig() (string, error) {
 		"layout":      d.config.UI.Layout,
 		"darkMode":    d.config.UI.DarkMode,
 		"showSidebar": d.config.UI.ShowSidebar,
+		// Values to prefill Try-It forms with, keyed by operationId
+		"tryItDefaults": d.TryItDefaults(),
 	}
 
 	data, err := json.Marshal(config)
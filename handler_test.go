@@ -0,0 +1,632 @@
+package openswag
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/andrianprasetya/open-swag-go/pkg/schema"
+	"github.com/andrianprasetya/open-swag-go/pkg/spec"
+	"github.com/andrianprasetya/open-swag-go/pkg/tryit"
+)
+
+func TestPrefersJSON(t *testing.T) {
+	tests := []struct {
+		accept   string
+		expected bool
+	}{
+		{"", false},
+		{"*/*", false},
+		{"application/json", true},
+		{"application/vnd.oai.openapi+json", true},
+		{"text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", false},
+		{"application/json, */*;q=0.8", true},
+		{"text/html;q=0.5, application/json", true},
+		{"application/json;q=0.5, text/html", false},
+	}
+
+	for _, tt := range tests {
+		if got := prefersJSON(tt.accept); got != tt.expected {
+			t.Errorf("prefersJSON(%q): expected %v, got %v", tt.accept, tt.expected, got)
+		}
+	}
+}
+
+func TestHandler_ContentNegotiation(t *testing.T) {
+	docs := New(Config{Info: Info{Title: "Test", Version: "1.0.0"}})
+	handler := docs.Handler()
+
+	req := httptest.NewRequest(http.MethodGet, "/docs/", nil)
+	req.Header.Set("Accept", "application/json")
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("expected JSON content type, got %q", ct)
+	}
+	if !strings.Contains(rec.Body.String(), `"openapi"`) {
+		t.Error("expected spec body")
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/docs/", nil)
+	req.Header.Set("Accept", "text/html")
+	rec = httptest.NewRecorder()
+	handler(rec, req)
+
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+		t.Errorf("expected HTML content type, got %q", ct)
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	docs := New(Config{
+		Info:     Info{Title: "Test", Version: "1.0.0"},
+		DocsAuth: &DocsAuth{Enabled: true, APIKey: "secret"},
+	})
+
+	serve := func(handler http.HandlerFunc, target string) {
+		handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, target, nil))
+	}
+	serve(docs.Handler(), "/docs/?key=secret")
+	serve(docs.SpecHandler(), "/docs/openapi.json?key=secret")
+	serve(docs.SpecHandler(), "/docs/openapi.json?key=wrong")
+
+	m := docs.Metrics()
+	if m.PageViews != 1 || m.SpecFetches != 1 || m.AuthFailures != 1 {
+		t.Errorf("unexpected metrics: %+v", m)
+	}
+}
+
+func TestBasicAuth_SessionCookie(t *testing.T) {
+	docs := New(Config{
+		Info:     Info{Title: "Test", Version: "1.0.0"},
+		DocsAuth: &DocsAuth{Enabled: true, APIKey: "secret", SessionSecret: "session-secret"},
+	})
+	handler := docs.SpecHandler()
+
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, "/docs/openapi.json?key=secret", nil))
+	cookies := rec.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != SessionCookieName {
+		t.Fatalf("expected session cookie, got %v", cookies)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/docs/openapi.json", nil)
+	req.AddCookie(cookies[0])
+	rec = httptest.NewRecorder()
+	handler(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected cookie to authorize request, got %d", rec.Code)
+	}
+
+	tampered := *cookies[0]
+	tampered.Value = "9999999999." + strings.Repeat("0", 64)
+	req = httptest.NewRequest(http.MethodGet, "/docs/openapi.json", nil)
+	req.AddCookie(&tampered)
+	rec = httptest.NewRecorder()
+	handler(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected tampered cookie to be rejected, got %d", rec.Code)
+	}
+}
+
+func TestProxyHandler_EndpointServers(t *testing.T) {
+	upload := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("stored"))
+	}))
+	defer upload.Close()
+
+	docs := New(Config{
+		Info:    Info{Title: "Test", Version: "1.0.0"},
+		Servers: []Server{{URL: "https://api.example.com"}},
+	})
+	handler := docs.ProxyHandler(tryit.DefaultConsoleConfig())
+	send := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/docs/proxy?url="+url.QueryEscape(upload.URL+"/uploads"), nil)
+		rec := httptest.NewRecorder()
+		handler(rec, req)
+		return rec
+	}
+
+	if rec := send(); rec.Code != http.StatusForbidden {
+		t.Errorf("expected an unknown host to be rejected, got %d", rec.Code)
+	}
+
+	// Endpoints registered after the handler was created count too
+	docs.Add(Endpoint{Method: "POST", Path: "/uploads", Servers: []Server{{URL: upload.URL}}})
+	if rec := send(); rec.Code != http.StatusOK || rec.Body.String() != "stored" {
+		t.Errorf("expected the endpoint's server to be proxied, got %d %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestProxyHandler_AllowedTargetsAndCredentials(t *testing.T) {
+	var seen http.Header
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = r.Header.Clone()
+		w.Write([]byte("ok"))
+	}))
+	defer upstream.Close()
+
+	docs := New(Config{
+		Info:     Info{Title: "Test", Version: "1.0.0"},
+		Servers:  []Server{{URL: upstream.URL + "/api"}},
+		DocsAuth: &DocsAuth{Enabled: true, Username: "docs", Password: "pass", SessionSecret: "session-secret"},
+	})
+	handler := docs.ProxyHandler(tryit.DefaultConsoleConfig())
+
+	proxied := func(target string, setup func(*http.Request)) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/docs/proxy?url="+url.QueryEscape(target), nil)
+		setup(req)
+		rec := httptest.NewRecorder()
+		handler(rec, req)
+		return rec
+	}
+
+	rec := proxied("http://169.254.169.254/latest/meta-data/", func(r *http.Request) { r.SetBasicAuth("docs", "pass") })
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("expected a target outside the servers to be rejected, got %d", rec.Code)
+	}
+
+	rec = proxied(upstream.URL+"/api/users", func(r *http.Request) { r.SetBasicAuth("docs", "pass") })
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected a configured server to be proxied, got %d", rec.Code)
+	}
+	if seen.Get("Authorization") != "" {
+		t.Errorf("expected the docs login not to be forwarded, got %q", seen.Get("Authorization"))
+	}
+	session := rec.Result().Cookies()[0]
+
+	rec = proxied(upstream.URL+"/api/users", func(r *http.Request) {
+		r.AddCookie(session)
+		r.AddCookie(&http.Cookie{Name: "theme", Value: "dark"})
+		r.Header.Set("Authorization", "Bearer api-token")
+	})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected the session to authorize the proxy, got %d", rec.Code)
+	}
+	if strings.Contains(seen.Get("Cookie"), SessionCookieName) || !strings.Contains(seen.Get("Cookie"), "theme=dark") {
+		t.Errorf("expected only the session cookie to be removed, got %q", seen.Get("Cookie"))
+	}
+	if seen.Get("Authorization") != "Bearer api-token" {
+		t.Errorf("expected the API credentials to be forwarded, got %q", seen.Get("Authorization"))
+	}
+}
+
+func TestSpecHandler_PrettyPrint(t *testing.T) {
+	compact := false
+	docs := New(Config{Info: Info{Title: "Test", Version: "1.0.0"}, PrettyPrint: &compact})
+	handler := docs.SpecHandler()
+
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, "/docs/openapi.json", nil))
+	if strings.Contains(rec.Body.String(), "\n") {
+		t.Errorf("expected compact JSON, got %s", rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, "/docs/openapi.json?pretty=true", nil))
+	if !strings.Contains(rec.Body.String(), "\n  ") {
+		t.Errorf("expected indented JSON with ?pretty=true, got %s", rec.Body.String())
+	}
+}
+
+func TestInternalEndpoints(t *testing.T) {
+	docs := New(Config{
+		Info:     Info{Title: "Test", Version: "1.0.0"},
+		DocsAuth: &DocsAuth{Enabled: true, APIKey: "secret"},
+	})
+	docs.AddAll(
+		Endpoint{Method: "GET", Path: "/users"},
+		Endpoint{Method: "POST", Path: "/admin/reindex", Internal: true},
+	)
+
+	if _, ok := docs.BuildSpec().Paths["/admin/reindex"]; ok {
+		t.Error("expected internal endpoint to be hidden from the public spec")
+	}
+	if _, ok := docs.BuildInternalSpec().Paths["/admin/reindex"]; !ok {
+		t.Error("expected internal endpoint in the full spec")
+	}
+
+	mux := http.NewServeMux()
+	docs.Mount(mux, "/docs")
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs/openapi.internal.json", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 without credentials, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs/openapi.internal.json?key=secret", nil))
+	if !strings.Contains(rec.Body.String(), "/admin/reindex") {
+		t.Errorf("expected full spec, got %s", rec.Body.String())
+	}
+}
+
+func TestSpecHandler_FilterByTags(t *testing.T) {
+	docs := New(Config{
+		Info: Info{Title: "Test", Version: "1.0.0"},
+		Tags: []Tag{{Name: "Users"}, {Name: "Orders"}},
+	})
+	docs.AddAll(
+		Endpoint{Method: "GET", Path: "/users", Tags: []string{"Users"}},
+		Endpoint{Method: "GET", Path: "/orders", Tags: []string{"Orders"}},
+	)
+	docs.BuildSpec().AddSchema("Order", &spec.Schema{Type: "object"})
+
+	rec := httptest.NewRecorder()
+	docs.SpecHandler()(rec, httptest.NewRequest(http.MethodGet, "/docs/openapi.json?tags=Users", nil))
+
+	var doc spec.OpenAPI
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := doc.Paths["/orders"]; ok {
+		t.Error("expected /orders to be filtered out")
+	}
+	if _, ok := doc.Paths["/users"]; !ok {
+		t.Error("expected /users to be kept")
+	}
+	if len(doc.Tags) != 1 || doc.Tags[0].Name != "Users" {
+		t.Errorf("expected only the Users tag, got %v", doc.Tags)
+	}
+	if doc.Components != nil && doc.Components.Schemas["Order"] != nil {
+		t.Error("expected unused Order schema to be pruned")
+	}
+}
+
+func TestSpecHandler_DynamicServers(t *testing.T) {
+	docs := New(Config{
+		Info:                  Info{Title: "Test", Version: "1.0.0"},
+		Servers:               []Server{{URL: "http://localhost:8080"}},
+		DynamicServers:        true,
+		TrustForwardedHeaders: true,
+		ServerBasePath:        "/api/v1",
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/docs/openapi.json", nil)
+	req.Host = "internal:8080"
+	req.Header.Set("X-Forwarded-Proto", "https")
+	req.Header.Set("X-Forwarded-Host", "api.example.com, proxy.local")
+	rec := httptest.NewRecorder()
+	docs.SpecHandler()(rec, req)
+
+	var doc spec.OpenAPI
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if len(doc.Servers) != 2 || doc.Servers[0].URL != "https://api.example.com/api/v1" || doc.Servers[1].URL != "http://localhost:8080" {
+		t.Errorf("expected request-derived server first, got %+v", doc.Servers)
+	}
+	if servers := docs.BuildSpec().Servers; len(servers) != 1 {
+		t.Errorf("expected the cached spec to keep its servers, got %+v", servers)
+	}
+}
+
+func TestSpecHandler_DynamicServersForwardedHeaders(t *testing.T) {
+	serverURL := func(trust bool, host string, headers map[string]string) string {
+		docs := New(Config{
+			Info:                  Info{Title: "Test", Version: "1.0.0"},
+			DynamicServers:        true,
+			TrustForwardedHeaders: trust,
+		})
+		req := httptest.NewRequest(http.MethodGet, "/docs/openapi.json", nil)
+		req.Host = host
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+		rec := httptest.NewRecorder()
+		docs.SpecHandler()(rec, req)
+
+		var doc spec.OpenAPI
+		if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+			t.Fatal(err)
+		}
+		if len(doc.Servers) == 0 {
+			return ""
+		}
+		return doc.Servers[0].URL
+	}
+
+	forwarded := map[string]string{"X-Forwarded-Proto": "https", "X-Forwarded-Host": "evil.example.com"}
+	tests := []struct {
+		name    string
+		trust   bool
+		host    string
+		headers map[string]string
+		want    string
+	}{
+		{"untrusted headers are ignored", false, "api.example.com", forwarded, "http://api.example.com"},
+		{"trusted headers", true, "internal:8080", forwarded, "https://evil.example.com"},
+		{"unknown scheme", true, "api.example.com", map[string]string{"X-Forwarded-Proto": "javascript"}, "http://api.example.com"},
+		{"invalid forwarded host", true, "api.example.com", map[string]string{"X-Forwarded-Host": "a.com/#<script>"}, ""},
+		{"IPv6 host", false, "[::1]:8080", nil, "http://[::1]:8080"},
+	}
+	for _, tt := range tests {
+		if got := serverURL(tt.trust, tt.host, tt.headers); got != tt.want {
+			t.Errorf("%s: expected server %q, got %q", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestSpecHandler_Cache(t *testing.T) {
+	docs := New(Config{Info: Info{Title: "Test", Version: "1.0.0"}})
+	docs.Add(Endpoint{Method: "GET", Path: "/users"})
+	handler := docs.SpecHandler()
+
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, "/docs/openapi.json", nil))
+	etag := rec.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("expected an ETag")
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/docs/openapi.json", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	handler(rec, req)
+	if rec.Code != http.StatusNotModified || rec.Body.Len() != 0 {
+		t.Errorf("expected 304 without a body, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, "/docs/openapi.json?pretty=false", nil))
+	if rec.Header().Get("ETag") == etag {
+		t.Error("expected a different ETag for the compact variant")
+	}
+
+	docs.Add(Endpoint{Method: "GET", Path: "/orders"})
+	rec = httptest.NewRecorder()
+	handler(rec, req)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "/orders") {
+		t.Errorf("expected the cache to be invalidated by Add, got %d", rec.Code)
+	}
+}
+
+// buildCounter counts spec builds through the Logger
+type buildCounter struct {
+	nopLogger
+	builds atomic.Int32
+}
+
+func (c *buildCounter) Info(msg string, args ...any) {
+	if msg == "openswag: spec rebuilt" {
+		c.builds.Add(1)
+	}
+}
+
+func TestSpecHandler_Concurrent(t *testing.T) {
+	logger := &buildCounter{}
+	docs := New(Config{
+		Info:          Info{Title: "Test", Version: "1.0.0"},
+		SpecCacheSize: -1,
+		Logger:        logger,
+	})
+	docs.Add(Endpoint{Method: "GET", Path: "/users"})
+	handler := docs.SpecHandler()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i%8 == 7 {
+				docs.Add(Endpoint{Method: "GET", Path: "/items/" + strconv.Itoa(i)})
+			}
+			rec := httptest.NewRecorder()
+			handler(rec, httptest.NewRequest(http.MethodGet, "/docs/openapi.json", nil))
+			if rec.Code != http.StatusOK {
+				t.Errorf("expected 200, got %d", rec.Code)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// Each Add invalidates once, so at most one build per Add plus the first
+	if builds := logger.builds.Load(); builds < 1 || builds > 5 {
+		t.Errorf("expected between 1 and 5 builds, got %d", builds)
+	}
+	if paths := len(docs.BuildSpec().Paths); paths != 5 {
+		t.Errorf("expected the final spec to have 5 paths, got %d", paths)
+	}
+}
+
+func TestMockHandler_FieldNaming(t *testing.T) {
+	type Account struct {
+		DisplayName string
+		CreatedAt   string
+	}
+
+	docs := New(Config{Info: Info{Title: "Test", Version: "1.0.0"}, FieldNaming: schema.FieldNamingSnake})
+	docs.Add(Endpoint{Method: "GET", Path: "/account", Responses: map[int]Response{200: {Schema: Account{}}}})
+
+	rec := httptest.NewRecorder()
+	docs.MockHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/account", nil))
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("expected a JSON body, got %s", rec.Body)
+	}
+	props := docs.BuildSpec().Paths["/account"].Get.Responses["200"].Content["application/json"].Schema.Properties
+	for name := range props {
+		if _, ok := body[name]; !ok {
+			t.Errorf("expected mock property %q from the spec, got %v", name, body)
+		}
+	}
+	if _, ok := body["display_name"]; !ok {
+		t.Errorf("expected snake_case names, got %v", body)
+	}
+}
+
+func TestMockHandler_ResponseWrappers(t *testing.T) {
+	type User struct {
+		ID string `json:"id"`
+	}
+
+	docs := New(Config{
+		Info: Info{Title: "Test", Version: "1.0.0"},
+		ResponseWrapper: Envelope("data", map[string]*spec.Schema{
+			"meta": {Type: "object", Properties: map[string]*spec.Schema{"version": {Type: "string", Example: "v1"}}},
+		}),
+		ErrorWrapper: Envelope("error", nil),
+	})
+	docs.AddAll(
+		Endpoint{Method: "GET", Path: "/users/{id}", Responses: map[int]Response{
+			200: {Schema: User{}, Examples: map[string]interface{}{"alice": User{ID: "u_1"}}},
+		}},
+		Endpoint{Method: "GET", Path: "/missing", Responses: map[int]Response{404: {Schema: User{}}}},
+		Endpoint{Method: "GET", Path: "/health", RawResponses: true, Responses: map[int]Response{
+			200: {Schema: User{}, Examples: map[string]interface{}{"ok": User{ID: "up"}}},
+		}},
+	)
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/users/1", `{"data":{"id":"u_1"},"meta":{"version":"v1"}}`},
+		{"/health", `{"id":"up"}`},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		docs.MockHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+		if got := strings.TrimSpace(rec.Body.String()); got != tt.want {
+			t.Errorf("%s: expected %s, got %s", tt.path, tt.want, got)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	docs.MockHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/missing", nil))
+	var body map[string]map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil || body["error"] == nil {
+		t.Errorf("expected the error envelope, got %s", rec.Body)
+	}
+}
+
+func TestMockHandler(t *testing.T) {
+	type User struct {
+		ID    string `json:"id"`
+		Email string `json:"email"`
+	}
+
+	docs := New(Config{Info: Info{Title: "Test", Version: "1.0.0"}})
+	docs.AddAll(
+		Endpoint{Method: "GET", Path: "/users/{id}", Responses: map[int]Response{
+			200: {Schema: User{}, Examples: map[string]interface{}{"alice": User{ID: "u_1", Email: "alice@example.com"}}},
+			404: {Description: "Not found"},
+		}},
+		Endpoint{Method: "POST", Path: "/users", Responses: map[int]Response{201: {Schema: User{}}, 400: {}}},
+		Endpoint{Method: "DELETE", Path: "/users/:id", Responses: map[int]Response{204: NoContentResponse("")}},
+	)
+	mock := docs.MockHandler()
+
+	rec := httptest.NewRecorder()
+	mock.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/42", nil))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"email":"alice@example.com"`) {
+		t.Errorf("expected documented example, got %d %s", rec.Code, rec.Body)
+	}
+
+	rec = httptest.NewRecorder()
+	mock.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/users", nil))
+	var created User
+	if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil || rec.Code != http.StatusCreated || created.Email == "" {
+		t.Errorf("expected generated 201 body, got %d %s", rec.Code, rec.Body)
+	}
+
+	rec = httptest.NewRecorder()
+	mock.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/users/42", nil))
+	if rec.Code != http.StatusNoContent || rec.Body.Len() != 0 || rec.Header().Get(MockHeader) != "true" {
+		t.Errorf("expected empty 204, got %d %q", rec.Code, rec.Body)
+	}
+
+	rec = httptest.NewRecorder()
+	mock.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/orders", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for undocumented route, got %d", rec.Code)
+	}
+}
+
+// logRecorder records every log call as "level msg key=value ..."
+type logRecorder struct {
+	mu      sync.Mutex
+	entries []string
+}
+
+func (l *logRecorder) record(level, msg string, args []any) {
+	var sb strings.Builder
+	sb.WriteString(level + " " + msg)
+	for i := 0; i+1 < len(args); i += 2 {
+		sb.WriteString(" " + fmt.Sprint(args[i]) + "=" + fmt.Sprint(args[i+1]))
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, sb.String())
+}
+
+func (l *logRecorder) Debug(msg string, args ...any) { l.record("DEBUG", msg, args) }
+func (l *logRecorder) Info(msg string, args ...any)  { l.record("INFO", msg, args) }
+func (l *logRecorder) Warn(msg string, args ...any)  { l.record("WARN", msg, args) }
+
+// matching returns the entries starting with prefix
+func (l *logRecorder) matching(prefix string) []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var found []string
+	for _, entry := range l.entries {
+		if strings.HasPrefix(entry, prefix) {
+			found = append(found, entry)
+		}
+	}
+	return found
+}
+
+func TestConfigLogger(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer upstream.Close()
+
+	logger := &logRecorder{}
+	docs := New(Config{
+		Info:     Info{Title: "Test", Version: "1.0.0"},
+		Servers:  []Server{{URL: upstream.URL}},
+		DocsAuth: &DocsAuth{Enabled: true, Username: "docs", Password: "pass"},
+		Logger:   logger,
+	})
+	docs.Add(Endpoint{Method: "GET", Path: "/users"})
+
+	docs.BuildSpec()
+	docs.BuildSpec()
+	rebuilds := logger.matching("INFO openswag: spec rebuilt")
+	if len(rebuilds) != 1 || !strings.Contains(rebuilds[0], "endpoints=1") || !strings.Contains(rebuilds[0], "internal=false") {
+		t.Errorf("expected one rebuild log for the memoized spec, got %v", rebuilds)
+	}
+
+	handler := docs.ProxyHandler(tryit.DefaultConsoleConfig())
+	proxied := func(password string) {
+		req := httptest.NewRequest(http.MethodGet, "/docs/proxy?url="+url.QueryEscape(upstream.URL+"/users"), nil)
+		req.SetBasicAuth("docs", password)
+		handler(httptest.NewRecorder(), req)
+	}
+
+	proxied("wrong")
+	if failures := logger.matching("WARN openswag: docs auth failed"); len(failures) != 1 || !strings.Contains(failures[0], "path=/docs/proxy") {
+		t.Errorf("expected an auth failure log, got %v", failures)
+	}
+	if hits := logger.matching("INFO openswag: proxy request"); len(hits) != 0 {
+		t.Errorf("expected no proxy log for a rejected login, got %v", hits)
+	}
+
+	proxied("pass")
+	hits := logger.matching("INFO openswag: proxy request")
+	if len(hits) != 1 || !strings.Contains(hits[0], "method=GET") || !strings.Contains(hits[0], "target="+upstream.URL+"/users") || !strings.Contains(hits[0], "status=202") {
+		t.Errorf("expected a proxy log with method, target and status, got %v", hits)
+	}
+}
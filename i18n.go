@@ -0,0 +1,110 @@
+package openswag
+
+import (
+	"encoding/json"
+
+	"github.com/andrianprasetya/open-swag-go/pkg/spec"
+)
+
+// translatableKeys are the spec fields whose string values get translated
+var translatableKeys = map[string]bool{
+	"title":       true,
+	"summary":     true,
+	"description": true,
+}
+
+// literalKeys hold user data that must never be translated
+var literalKeys = map[string]bool{
+	"example":  true,
+	"examples": true,
+	"default":  true,
+	"enum":     true,
+	"const":    true,
+}
+
+// AddTranslations registers translated strings for a language.
+// Keys are the description strings used in the spec (either plain text in the
+// default language or dedicated keys such as "users.create.summary").
+func (d *Docs) AddTranslations(lang string, translations map[string]string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.translations == nil {
+		d.translations = make(map[string]map[string]string)
+	}
+	if d.translations[lang] == nil {
+		d.translations[lang] = make(map[string]string)
+	}
+	for key, value := range translations {
+		d.translations[lang][key] = value
+	}
+	d.specCache.clear()
+}
+
+// SpecJSONLang returns the OpenAPI spec as JSON with titles, summaries and
+// descriptions translated into lang, falling back to the default language
+func (d *Docs) SpecJSONLang(lang string) ([]byte, error) {
+	openapi, err := d.buildSpec(d.config.IncludeInternal)
+	if err != nil {
+		return nil, err
+	}
+	return d.specJSON(openapi, lang, d.prettyPrint())
+}
+
+// specJSON renders the translated spec, indented when pretty is set
+func (d *Docs) specJSON(openapi *spec.OpenAPI, lang string, pretty bool) ([]byte, error) {
+	d.mu.RLock()
+	if lang == "" {
+		lang = d.config.DefaultLanguage
+	}
+	primary := d.translations[lang]
+	fallback := d.translations[d.config.DefaultLanguage]
+	d.mu.RUnlock()
+
+	if len(primary) == 0 && len(fallback) == 0 {
+		return marshalSpec(openapi, pretty)
+	}
+
+	data, err := json.Marshal(openapi)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	translate(doc, func(s string) string {
+		if t, ok := primary[s]; ok {
+			return t
+		}
+		if t, ok := fallback[s]; ok {
+			return t
+		}
+		return s
+	})
+
+	return marshalSpec(doc, pretty)
+}
+
+// translate walks a decoded spec and replaces translatable strings in place
+func translate(node interface{}, lookup func(string) string) {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if literalKeys[key] {
+				continue
+			}
+			if s, ok := child.(string); ok && translatableKeys[key] {
+				v[key] = lookup(s)
+				continue
+			}
+			translate(child, lookup)
+		}
+	case []interface{}:
+		for _, child := range v {
+			translate(child, lookup)
+		}
+	}
+}
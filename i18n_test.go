@@ -0,0 +1,55 @@
+package openswag
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSpecJSONLang(t *testing.T) {
+	docs := New(Config{
+		Info:            Info{Title: "api.title", Version: "1.0.0"},
+		DefaultLanguage: "en",
+	})
+	docs.Add(Endpoint{
+		Method:      "GET",
+		Path:        "/users",
+		Summary:     "users.list",
+		Description: "users.list.description",
+		Responses:   map[int]Response{200: {Description: "ok"}},
+	})
+	docs.AddTranslations("en", map[string]string{
+		"api.title":              "Users API",
+		"users.list":             "List users",
+		"users.list.description": "Returns all users",
+	})
+	docs.AddTranslations("id", map[string]string{
+		"users.list": "Daftar pengguna",
+	})
+
+	data, err := docs.SpecJSONLang("id")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var doc struct {
+		Info  struct{ Title string }
+		Paths map[string]map[string]struct {
+			Summary     string
+			Description string
+		}
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	if doc.Info.Title != "Users API" {
+		t.Errorf("expected fallback title, got %q", doc.Info.Title)
+	}
+	op := doc.Paths["/users"]["get"]
+	if op.Summary != "Daftar pengguna" {
+		t.Errorf("expected translated summary, got %q", op.Summary)
+	}
+	if op.Description != "Returns all users" {
+		t.Errorf("expected fallback description, got %q", op.Description)
+	}
+}
@@ -0,0 +1,40 @@
+package openswag
+
+import "github.com/andrianprasetya/open-swag-go/pkg/spec"
+
+// IdempotencyKeyHeader is the request header documented by IdempotencyKeyParam
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+const idempotencyNote = "Send an `Idempotency-Key` header to retry safely: a repeated " +
+	"request with the same key returns the original response instead of performing the operation again."
+
+// IdempotencyKeyParam creates the optional Idempotency-Key header parameter
+// accepted by mutating endpoints that can be retried safely
+func IdempotencyKeyParam() Parameter {
+	return HeaderParam(IdempotencyKeyHeader, "Unique key (e.g. a UUID) identifying the request; retries with the same key are replayed").
+		WithSchema(&spec.Schema{
+			Type:    "string",
+			Format:  "uuid",
+			Example: "550e8400-e29b-41d4-a716-446655440000",
+		})
+}
+
+// applyIdempotency documents the Idempotency-Key header and its replay
+// behavior on endpoints marked Idempotent
+func applyIdempotency(ep Endpoint, op *spec.Operation) {
+	if !ep.Idempotent {
+		return
+	}
+
+	if !hasParam(op.Parameters, IdempotencyKeyHeader) {
+		param := IdempotencyKeyParam()
+		op.AddParameter(spec.HeaderParam(param.Name).
+			WithDescription(param.Description).
+			WithSchema(param.Schema))
+	}
+
+	if op.Description != "" {
+		op.Description += "\n\n"
+	}
+	op.Description += idempotencyNote
+}
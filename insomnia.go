@@ -0,0 +1,181 @@
+package openswag
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+type insomniaExport struct {
+	Type         string             `json:"_type"`
+	ExportFormat int                `json:"__export_format"`
+	ExportDate   string             `json:"__export_date"`
+	ExportSource string             `json:"__export_source"`
+	Resources    []insomniaResource `json:"resources"`
+}
+
+// insomniaResource is a workspace, environment, request_group or request,
+// told apart by Type
+type insomniaResource struct {
+	ID             string                 `json:"_id"`
+	Type           string                 `json:"_type"`
+	ParentID       *string                `json:"parentId"`
+	Name           string                 `json:"name"`
+	Description    string                 `json:"description,omitempty"`
+	Scope          string                 `json:"scope,omitempty"`
+	Data           map[string]string      `json:"data,omitempty"`
+	Method         string                 `json:"method,omitempty"`
+	URL            string                 `json:"url,omitempty"`
+	Parameters     []insomniaPair         `json:"parameters,omitempty"`
+	PathParameters []insomniaPair         `json:"pathParameters,omitempty"`
+	Headers        []insomniaPair         `json:"headers,omitempty"`
+	Body           *insomniaBody          `json:"body,omitempty"`
+	Authentication map[string]interface{} `json:"authentication,omitempty"`
+}
+
+type insomniaBody struct {
+	MimeType string         `json:"mimeType"`
+	Text     string         `json:"text,omitempty"`
+	Params   []insomniaPair `json:"params,omitempty"`
+}
+
+type insomniaPair struct {
+	Name        string `json:"name"`
+	Value       string `json:"value"`
+	Description string `json:"description,omitempty"`
+	Disabled    bool   `json:"disabled,omitempty"`
+}
+
+// ToInsomnia converts the public spec into an Insomnia v4 export: a
+// workspace, a base environment holding baseUrl (the first server), one
+// request group per operation's first tag and one request per operation.
+// Requests are built the same way as for ToPostmanCollection; credentials
+// are left as environment variables.
+func (d *Docs) ToInsomnia() ([]byte, error) {
+	openapi, requests, err := d.exportRequests()
+	if err != nil {
+		return nil, err
+	}
+	defaultAuth := exportAuth(openapi, openapi.Security)
+
+	workspaceID := "wrk_openswag"
+	export := insomniaExport{
+		Type:         "export",
+		ExportFormat: 4,
+		ExportDate:   time.Now().UTC().Format(time.RFC3339),
+		ExportSource: "open-swag-go",
+		Resources: []insomniaResource{
+			{
+				ID:          workspaceID,
+				Type:        "workspace",
+				Name:        d.config.Info.Title,
+				Description: d.config.Info.Description,
+				Scope:       "collection",
+			},
+			{
+				ID:       "env_openswag",
+				Type:     "environment",
+				ParentID: &workspaceID,
+				Name:     "Base Environment",
+				Data:     map[string]string{"baseUrl": d.exportBaseURL()},
+			},
+		},
+	}
+
+	groups := make(map[string]string)
+	for i, req := range requests {
+		parentID := workspaceID
+		if req.Folder != "" {
+			id, ok := groups[req.Folder]
+			if !ok {
+				id = "fld_" + intToString(len(groups)+1)
+				groups[req.Folder] = id
+				export.Resources = append(export.Resources, insomniaResource{
+					ID:       id,
+					Type:     "request_group",
+					ParentID: &workspaceID,
+					Name:     req.Folder,
+				})
+			}
+			parentID = id
+		}
+
+		resource := insomniaRequestFor(req, defaultAuth)
+		resource.ID = "req_" + intToString(i+1)
+		resource.ParentID = &parentID
+		export.Resources = append(export.Resources, resource)
+	}
+
+	return json.MarshalIndent(export, "", "  ")
+}
+
+// insomniaRequestFor renders an exported request. Insomnia has no
+// collection-wide auth, so requests without their own use defaultAuth.
+func insomniaRequestFor(req exportedRequest, defaultAuth *exportedAuth) insomniaResource {
+	resource := insomniaResource{
+		Type:        "request",
+		Name:        req.Name,
+		Description: req.Description,
+		Method:      req.Method,
+	}
+
+	host := "{{ _.baseUrl }}"
+	if req.Server != "" {
+		host = req.Server
+	}
+	resource.URL = host + "/" + strings.Join(req.Path, "/")
+
+	for _, p := range req.PathParams {
+		resource.PathParameters = append(resource.PathParameters, insomniaPair{Name: p.Name, Value: p.Value, Description: p.Description})
+	}
+	for _, p := range req.Query {
+		resource.Parameters = append(resource.Parameters, insomniaPair{Name: p.Name, Value: p.Value, Description: p.Description, Disabled: !p.Required})
+	}
+	for _, p := range req.Headers {
+		resource.Headers = append(resource.Headers, insomniaPair{Name: p.Name, Value: p.Value, Description: p.Description, Disabled: !p.Required})
+	}
+
+	if req.ContentType != "" {
+		resource.Headers = append(resource.Headers, insomniaPair{Name: "Content-Type", Value: req.ContentType})
+		resource.Body = &insomniaBody{MimeType: req.ContentType}
+		switch req.ContentType {
+		case ContentTypeURLEncoded, ContentTypeMultipart:
+			for _, f := range exportFields(req.Body) {
+				resource.Body.Params = append(resource.Body.Params, insomniaPair{Name: f.Name, Value: f.Value})
+			}
+		default:
+			resource.Body.Text = exportJSON(req.Body)
+		}
+	}
+
+	auth := req.Auth
+	if auth == nil {
+		auth = defaultAuth
+	}
+	resource.Authentication = insomniaAuthFor(auth)
+	return resource
+}
+
+// insomniaAuthFor renders exported auth, with credentials as environment variables
+func insomniaAuthFor(auth *exportedAuth) map[string]interface{} {
+	if auth == nil {
+		return nil
+	}
+	switch auth.Type {
+	case "bearer":
+		return map[string]interface{}{"type": "bearer", "token": "{{ _.bearerToken }}"}
+	case "basic":
+		return map[string]interface{}{"type": "basic", "username": "{{ _.username }}", "password": "{{ _.password }}"}
+	case "apikey":
+		addTo := "header"
+		if auth.In == "query" {
+			addTo = "queryParams"
+		} else if auth.In == "cookie" {
+			addTo = "cookie"
+		}
+		return map[string]interface{}{"type": "apikey", "key": auth.Name, "value": "{{ _.apiKey }}", "addTo": addTo}
+	case "oauth2":
+		return map[string]interface{}{"type": "oauth2", "grantType": "authorization_code"}
+	}
+	return map[string]interface{}{"type": "none"}
+}
@@ -0,0 +1,99 @@
+package openswag
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+)
+
+// fileConfig holds the Config fields that may come from a file; endpoints
+// and everything behavioral stay in code
+type fileConfig struct {
+	Info     Info          `json:"info"`
+	Servers  []Server      `json:"servers,omitempty"`
+	Tags     []Tag         `json:"tags,omitempty"`
+	UI       UIConfig      `json:"ui"`
+	DocsAuth *fileDocsAuth `json:"docsAuth,omitempty"`
+}
+
+// fileDocsAuth reads DocsAuth with the session TTL written as a duration
+type fileDocsAuth struct {
+	DocsAuth
+	SessionTTL fileDuration `json:"sessionTtl,omitempty"`
+}
+
+// fileDuration is a time.Duration read from a string such as "30m" or,
+// like time.Duration itself, from a number of nanoseconds
+type fileDuration time.Duration
+
+// UnmarshalJSON implements json.Unmarshaler
+func (d *fileDuration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		var n int64
+		if err := json.Unmarshal(data, &n); err != nil {
+			return fmt.Errorf("invalid duration %s", data)
+		}
+		*d = fileDuration(n)
+		return nil
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = fileDuration(v)
+	return nil
+}
+
+// LoadConfig reads the info, servers, tags, ui and docsAuth settings from a
+// JSON or YAML file (by extension: .yaml/.yml, anything else is JSON),
+// using the Config json keys; docsAuth.sessionTtl is a duration such as "30m". Other keys are rejected so settings that must
+// be made in code don't silently vanish. Set the remaining fields on the
+// result before passing it to New:
+//
+//	cfg, err := openswag.LoadConfig("docs.yaml")
+//	cfg.Security = []string{openswag.SecurityBearerAuth}
+//	docs := openswag.New(cfg)
+func LoadConfig(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		var doc interface{}
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return Config{}, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		if data, err = json.Marshal(doc); err != nil {
+			return Config{}, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+	}
+
+	var fc fileConfig
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&fc); err != nil {
+		return Config{}, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	cfg := Config{
+		Info:    fc.Info,
+		Servers: fc.Servers,
+		Tags:    fc.Tags,
+		UI:      fc.UI,
+	}
+	if fc.DocsAuth != nil {
+		auth := fc.DocsAuth.DocsAuth
+		auth.SessionTTL = time.Duration(fc.DocsAuth.SessionTTL)
+		cfg.DocsAuth = &auth
+	}
+	return cfg, nil
+}
@@ -0,0 +1,61 @@
+package openswag
+
+import (
+	"expvar"
+	"sync"
+	"sync/atomic"
+)
+
+// Metrics is a snapshot of docs server usage counters
+type Metrics struct {
+	PageViews    uint64 `json:"pageViews"`
+	SpecFetches  uint64 `json:"specFetches"`
+	AuthFailures uint64 `json:"authFailures"`
+	// ProxyRequests counts proxied Try-It requests by response status code
+	ProxyRequests map[int]uint64 `json:"proxyRequests"`
+}
+
+// metrics holds the live counters behind Docs.Metrics
+type metrics struct {
+	pageViews    atomic.Uint64
+	specFetches  atomic.Uint64
+	authFailures atomic.Uint64
+
+	mu            sync.Mutex
+	proxyRequests map[int]uint64
+}
+
+func (m *metrics) recordProxy(status int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.proxyRequests == nil {
+		m.proxyRequests = make(map[int]uint64)
+	}
+	m.proxyRequests[status]++
+}
+
+// Metrics returns a snapshot of the docs server usage counters
+func (d *Docs) Metrics() Metrics {
+	snapshot := Metrics{
+		PageViews:     d.metrics.pageViews.Load(),
+		SpecFetches:   d.metrics.specFetches.Load(),
+		AuthFailures:  d.metrics.authFailures.Load(),
+		ProxyRequests: make(map[int]uint64),
+	}
+
+	d.metrics.mu.Lock()
+	for status, count := range d.metrics.proxyRequests {
+		snapshot.ProxyRequests[status] = count
+	}
+	d.metrics.mu.Unlock()
+
+	return snapshot
+}
+
+// PublishExpvar exposes the metrics under the given name on /debug/vars.
+// Like expvar.Publish, it panics if the name is already in use.
+func (d *Docs) PublishExpvar(name string) {
+	expvar.Publish(name, expvar.Func(func() any {
+		return d.Metrics()
+	}))
+}
@@ -0,0 +1,181 @@
+package openswag
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/andrianprasetya/open-swag-go/pkg/examples"
+	"github.com/andrianprasetya/open-swag-go/pkg/spec"
+)
+
+// MockHeader marks responses served by MockHandler
+const MockHeader = "X-Mock-Response"
+
+// MockHandler returns a handler that answers requests for documented
+// endpoints with the example of their first 2xx response (or the lowest
+// documented status), generating one with fake data when none is documented.
+// JSON bodies are wrapped in the configured response or error envelope.
+// It never calls Endpoint.Handler; mount it separately from the real API so
+// clients can develop against the spec before the backend exists.
+func (d *Docs) MockHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		d.mu.RLock()
+		i, ok := d.matchEndpoint(r.Method, r.URL.Path)
+		var ep Endpoint
+		if ok {
+			ep = d.endpoints[i]
+		}
+		d.mu.RUnlock()
+
+		if !ok {
+			http.Error(w, "no documented endpoint matches "+r.Method+" "+r.URL.Path, http.StatusNotFound)
+			return
+		}
+
+		code, resp := mockResponse(ep)
+		w.Header().Set(MockHeader, "true")
+
+		body := d.mockBody(resp)
+		if body != nil {
+			body = d.wrapMockBody(ep, code, resp.ContentType, body)
+		}
+		if body == nil {
+			if resp.ContentType != "" {
+				w.Header().Set("Content-Type", resp.ContentType)
+			}
+			w.WriteHeader(code)
+			return
+		}
+
+		contentType := resp.ContentType
+		if contentType == "" {
+			contentType = ContentTypeJSON
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(code)
+		if s, ok := body.(string); ok && contentType != ContentTypeJSON {
+			w.Write([]byte(s))
+			return
+		}
+		json.NewEncoder(w).Encode(body)
+	})
+}
+
+// mockResponse picks the lowest 2xx response, or the lowest documented status
+// when there is none, defaulting to an empty 200
+func mockResponse(ep Endpoint) (int, Response) {
+	codes := make([]int, 0, len(ep.Responses))
+	for code := range ep.Responses {
+		codes = append(codes, code)
+	}
+	if len(codes) == 0 {
+		return http.StatusOK, Response{}
+	}
+	sort.Ints(codes)
+
+	for _, code := range codes {
+		if code >= 200 && code < 300 {
+			return code, ep.Responses[code]
+		}
+	}
+	return codes[0], ep.Responses[codes[0]]
+}
+
+// mockBody returns the first inline documented example by name, or one
+// generated from the response schema
+func (d *Docs) mockBody(resp Response) interface{} {
+	names := make([]string, 0, len(resp.Examples))
+	for name := range resp.Examples {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		switch ex := resp.Examples[name].(type) {
+		case Example:
+			if ex.ExternalValue == "" {
+				return ex.Value
+			}
+		case *Example:
+			if ex != nil && ex.ExternalValue == "" {
+				return ex.Value
+			}
+		default:
+			return ex
+		}
+	}
+
+	if resp.Schema == nil || resp.Streaming {
+		return nil
+	}
+	gen := examples.New(examples.Config{UseFaker: true, FieldNaming: d.config.FieldNaming})
+	return gen.GenerateFor(resp.Schema, examples.ContextResponse)
+}
+
+// wrapMockBody puts a JSON mock body in the envelope of Config.ResponseWrapper
+// (2xx) or Config.ErrorWrapper (4xx/5xx), matching the schema documented by
+// applyResponseWrappers. The envelope's other properties get their defaults
+// or examples.
+func (d *Docs) wrapMockBody(ep Endpoint, code int, contentType string, body interface{}) interface{} {
+	if ep.RawResponses {
+		return body
+	}
+	if contentType != "" && contentType != ContentTypeJSON && !strings.HasSuffix(contentType, "+json") {
+		return body
+	}
+
+	var wrap SchemaWrapper
+	switch {
+	case code >= 200 && code < 300:
+		wrap = d.config.ResponseWrapper
+	case code >= 400 && code < 600:
+		wrap = d.config.ErrorWrapper
+	}
+	if wrap == nil {
+		return body
+	}
+
+	// The wrapper places this marker where the response schema goes
+	inner := &spec.Schema{}
+	return envelopeValue(wrap(inner), inner, body, 0)
+}
+
+// envelopeValue builds the value of an envelope schema, substituting body
+// for the inner schema
+func envelopeValue(s, inner *spec.Schema, body interface{}, depth int) interface{} {
+	if s == inner {
+		return body
+	}
+	if s == nil || depth > 8 {
+		return nil
+	}
+
+	switch {
+	case len(s.AllOf) > 0:
+		merged := make(map[string]interface{})
+		for _, sub := range s.AllOf {
+			if obj, ok := envelopeValue(sub, inner, body, depth+1).(map[string]interface{}); ok {
+				for name, value := range obj {
+					merged[name] = value
+				}
+			}
+		}
+		return merged
+	case len(s.Properties) > 0:
+		obj := make(map[string]interface{})
+		for name, prop := range s.Properties {
+			if value := envelopeValue(prop, inner, body, depth+1); value != nil {
+				obj[name] = value
+			}
+		}
+		return obj
+	}
+	if value := schemaDefault(nil, s, depth); value != nil {
+		return value
+	}
+	if s.Type == "object" {
+		return map[string]interface{}{}
+	}
+	return nil
+}
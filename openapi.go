@@ -2,9 +2,13 @@ package openswag
 
 import (
 	"encoding/json"
+	"fmt"
+	"net/http"
 	"reflect"
+	"regexp"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/andrianprasetya/open-swag-go/pkg/schema"
 	"github.com/andrianprasetya/open-swag-go/pkg/spec"
@@ -12,16 +16,29 @@ import (
 
 // Docs is the main documentation instance
 type Docs struct {
-	config    Config
-	endpoints []Endpoint
-	openapi   *spec.OpenAPI
-	mu        sync.RWMutex
+	config           Config
+	endpoints        []Endpoint
+	webhooks         map[string]Webhook
+	validationErrors interface{}
+	specCache        *specCache
+	openapi          *spec.OpenAPI
+	internalSpec     *spec.OpenAPI
+	specErr          error
+	internalSpecErr  error
+	definitions      map[string]*schema.Schema
+	translations     map[string]map[string]string
+	metrics          metrics
+	patches          []schemaPatch
+	patchErrors      []error
+	mutators         []func(*spec.OpenAPI) error
+	mu               sync.RWMutex
 }
 
 // Endpoint represents an API endpoint definition
 type Endpoint struct {
 	Method      string
 	Path        string
+	OperationID string
 	Summary     string
 	Description string
 	Tags        []string
@@ -31,7 +48,40 @@ type Endpoint struct {
 	RequestBody *RequestBody
 	Responses   map[int]Response
 	Security    []string
-	Deprecated  bool
+	// Public opts the endpoint out of Config.Security and is emitted as "security": []
+	Public     bool
+	Deprecated bool
+	// Internal hides the endpoint from the public spec; it is only served by
+	// InternalSpecHandler (or BuildSpec when Config.IncludeInternal is set)
+	Internal bool
+	// SunsetDate (RFC 1123 or RFC 3339) marks the endpoint deprecated and
+	// documents the Sunset and Deprecation response headers
+	SunsetDate string
+	// Deprecation marks the endpoint deprecated with a reason and optional sunset date
+	Deprecation *Deprecation
+	// RateLimit documents the endpoint's rate limit and X-RateLimit-* headers
+	RateLimit *RateLimit
+	// Idempotent documents the Idempotency-Key header and its replay behavior
+	Idempotent bool
+	// CacheControl documents the Cache-Control header of success responses
+	// (e.g. Cacheable(300)), emitted as the x-cache-control extension
+	CacheControl string
+	// RawResponses opts the endpoint out of Config.ResponseWrapper and
+	// Config.ErrorWrapper, e.g. for file downloads or health checks
+	RawResponses bool
+	// SLA documents the expected latency, emitted as the x-sla extension
+	SLA *SLA
+	// Timeout documents how long a slow endpoint may take (x-timeout-ms) and
+	// replaces the Try-It console's RequestTimeout for it; zero keeps the default
+	Timeout time.Duration
+	// Servers overrides the global servers for this endpoint (e.g. an upload host)
+	Servers []Server
+	// Handler is the endpoint's implementation, used to capture real response
+	// examples when Config.CaptureExamples is enabled
+	Handler http.HandlerFunc
+	// Callbacks documents out-of-band requests the API makes back to the caller,
+	// keyed by runtime expression (e.g. "{$request.body#/callbackUrl}")
+	Callbacks map[string]Endpoint
 }
 
 // Parameter represents an API parameter
@@ -40,8 +90,21 @@ type Parameter struct {
 	In          string // "path", "query", "header", "cookie"
 	Description string
 	Required    bool
+	Deprecated  bool
 	Schema      *spec.Schema
 	Example     interface{}
+	// Examples holds named examples (plain values or Example); OpenAPI
+	// forbids combining them with Example, which wins when both are set
+	Examples map[string]interface{}
+	// Style and Explode control serialization, e.g. "matrix" or "label" for
+	// array path parameters (see WithStyle)
+	Style   string
+	Explode bool
+	// AllowEmptyValue and AllowReserved apply to query parameters: the first
+	// documents a valueless flag (?verbose), the second that reserved
+	// characters (:/?#[]@!$&'()*+,;=) are sent without percent-encoding
+	AllowEmptyValue bool
+	AllowReserved   bool
 }
 
 // RequestBody represents a request body
@@ -50,27 +113,91 @@ type RequestBody struct {
 	Required    bool
 	Schema      interface{}
 	ContentType string
+	// Examples holds named examples of the body; values are inlined unless
+	// they are an Example such as ExternalExample(url)
+	Examples map[string]interface{}
+	// Encoding documents the content type and headers of individual parts
+	// of a multipart or urlencoded body, keyed by property name
+	Encoding map[string]PartEncoding
 }
 
 // Response represents an API response
 type Response struct {
 	Description string
 	Schema      interface{}
+	// ContentType overrides the default application/json media type.
+	// Without a Schema, a non-JSON content type is documented as a binary body.
+	ContentType string
+	// Streaming documents an open-ended body (SSE, chunked download) as a
+	// plain string schema with x-streaming: true
+	Streaming bool
+	// Events documents the payload of each server-sent event of a streaming
+	// response, keyed by event name, as the x-sse-events extension
+	Events map[string]interface{}
+	// Content documents additional media types for the same status, each with
+	// its own schema (e.g. "application/vnd.myapi.v2+json": UserV2{})
+	Content map[string]interface{}
+	// Examples holds named examples of the primary media type; values are
+	// inlined unless they are an Example such as ExternalExample(url)
+	Examples map[string]interface{}
+	Links    map[string]Link
+	// Prefer documents the bodies returned for RFC 7240 preferences, keyed
+	// by preference (e.g. "return=minimal": nil for an empty body), as the
+	// x-prefer-variants extension
+	Prefer map[string]interface{}
+	// Headers documents the headers sent with the response, keyed by name
+	Headers map[string]ResponseHeader
 }
 
-// New creates a new documentation instance
+// ResponseHeader documents a response header; a nil Schema means a string
+type ResponseHeader struct {
+	Description string
+	Schema      *spec.Schema
+	Example     interface{}
+}
+
+// Link describes how values from a response can be used as input to another operation
+type Link struct {
+	OperationID string
+	Description string
+	// Parameters maps target parameter names to runtime expressions (e.g. "$response.body#/id")
+	Parameters  map[string]string
+	RequestBody interface{}
+}
+
+// New creates a new documentation instance. It panics when the config is
+// invalid, e.g. an OpenAPIVersion outside spec.SupportedVersions; use NewE
+// to get the error instead.
 func New(config Config) *Docs {
+	docs, err := NewE(config)
+	if err != nil {
+		panic("openswag: " + err.Error())
+	}
+	return docs
+}
+
+// NewE is New returning an invalid config as an error instead of panicking
+func NewE(config Config) (*Docs, error) {
+	if config.OpenAPIVersion != "" {
+		if err := spec.ValidateVersion(config.OpenAPIVersion); err != nil {
+			return nil, err
+		}
+	}
 	if config.UI.Theme == "" {
 		config.UI.Theme = "purple"
 	}
 	if config.UI.Layout == "" {
 		config.UI.Layout = "modern"
 	}
+	if config.Logger == nil {
+		config.Logger = nopLogger{}
+	}
 
 	return &Docs{
 		config:    config,
 		endpoints: make([]Endpoint, 0),
-	}
+		specCache: newSpecCache(config.SpecCacheSize),
+	}, nil
 }
 
 // Add registers an endpoint
@@ -78,7 +205,16 @@ func (d *Docs) Add(endpoint Endpoint) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 	d.endpoints = append(d.endpoints, endpoint)
+	d.invalidate()
+}
+
+// invalidate drops the cached specs and served variants; callers must hold d.mu
+func (d *Docs) invalidate() {
 	d.openapi = nil
+	d.internalSpec = nil
+	d.specErr = nil
+	d.internalSpecErr = nil
+	d.specCache.clear()
 }
 
 // AddAll registers multiple endpoints
@@ -88,17 +224,90 @@ func (d *Docs) AddAll(endpoints ...Endpoint) {
 	}
 }
 
-// BuildSpec generates the OpenAPI spec
+// AddGroup registers endpoints sharing a router group: prefix is prepended to
+// every path and security applies to endpoints that neither set their own
+// Security nor are marked Public
+func (d *Docs) AddGroup(prefix string, security []string, endpoints ...Endpoint) {
+	prefix = strings.TrimSuffix(prefix, "/")
+	for _, ep := range endpoints {
+		if ep.Path == "" || ep.Path[0] != '/' {
+			ep.Path = "/" + ep.Path
+		}
+		ep.Path = prefix + ep.Path
+		if ep.Security == nil && !ep.Public {
+			ep.Security = security
+		}
+		d.Add(ep)
+	}
+}
+
+// BuildSpec generates the OpenAPI spec. Internal endpoints are left out
+// unless Config.IncludeInternal is set. It never returns nil: when a mutator
+// registered with Use fails, the spec is returned as far as the mutators got
+// (see BuildSpecE for the error).
 func (d *Docs) BuildSpec() *spec.OpenAPI {
+	openapi, _ := d.buildSpec(d.config.IncludeInternal)
+	return openapi
+}
+
+// BuildSpecE is BuildSpec for callers that want to know whether a mutator
+// failed; on failure it returns nil and the mutator error
+func (d *Docs) BuildSpecE() (*spec.OpenAPI, error) {
+	openapi, err := d.buildSpec(d.config.IncludeInternal)
+	if err != nil {
+		return nil, err
+	}
+	return openapi, nil
+}
+
+// BuildInternalSpec generates the full OpenAPI spec, including internal
+// endpoints. Like BuildSpec, it never returns nil.
+func (d *Docs) BuildInternalSpec() *spec.OpenAPI {
+	openapi, _ := d.buildSpec(true)
+	return openapi
+}
+
+// BuildInternalSpecE is BuildInternalSpec returning the mutator error, like BuildSpecE
+func (d *Docs) BuildInternalSpecE() (*spec.OpenAPI, error) {
+	openapi, err := d.buildSpec(true)
+	if err != nil {
+		return nil, err
+	}
+	return openapi, nil
+}
+
+// buildSpec returns the memoized spec, building it once per invalidation.
+// Concurrent readers of a built spec only share the read lock; concurrent
+// first calls wait for a single build instead of each building their own.
+// A failed build is memoized too: the spec is returned along with the
+// mutator error until the next change invalidates it.
+func (d *Docs) buildSpec(includeInternal bool) (*spec.OpenAPI, error) {
+	d.mu.RLock()
+	cached, cachedErr := d.openapi, d.specErr
+	if includeInternal {
+		cached, cachedErr = d.internalSpec, d.internalSpecErr
+	}
+	d.mu.RUnlock()
+	if cached != nil {
+		return cached, cachedErr
+	}
+
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
-	if d.openapi != nil {
-		return d.openapi
+	cache, errCache := &d.openapi, &d.specErr
+	if includeInternal {
+		cache, errCache = &d.internalSpec, &d.internalSpecErr
+	}
+	// Another goroutine may have built it while this one waited for the lock
+	if *cache != nil {
+		return *cache, *errCache
 	}
 
 	info := spec.NewInfo(d.config.Info.Title, d.config.Info.Version).
-		WithDescription(d.config.Info.Description)
+		WithDescription(d.config.Info.Description).
+		WithSummary(d.config.Info.Summary).
+		WithTermsOfService(d.config.Info.TermsOfService)
 
 	if d.config.Info.Contact != nil {
 		info = info.WithContact(
@@ -110,9 +319,13 @@ func (d *Docs) BuildSpec() *spec.OpenAPI {
 
 	if d.config.Info.License != nil {
 		info = info.WithLicense(d.config.Info.License.Name, d.config.Info.License.URL)
+		info.License.Identifier = d.config.Info.License.Identifier
 	}
 
 	openapi := spec.NewOpenAPI(info)
+	if d.config.OpenAPIVersion != "" {
+		openapi.OpenAPI = d.config.OpenAPIVersion
+	}
 
 	// Add servers
 	for _, srv := range d.config.Servers {
@@ -124,25 +337,68 @@ func (d *Docs) BuildSpec() *spec.OpenAPI {
 		openapi.AddTag(spec.Tag{Name: tag.Name, Description: tag.Description})
 	}
 
+	// Add global security
+	for _, secName := range d.config.Security {
+		openapi.Security = append(openapi.Security, spec.SecurityRequirement{secName: {}})
+	}
+
 	// Build paths from endpoints
-	for _, ep := range d.endpoints {
-		d.addEndpointToSpec(openapi, ep)
+	d.definitions = make(map[string]*schema.Schema)
+	endpoints := make([]Endpoint, 0, len(d.endpoints))
+	ids := d.operationIDs()
+	for i, ep := range d.endpoints {
+		if ep.Internal && !includeInternal {
+			continue
+		}
+		ep.OperationID = ids[i]
+		if err := d.addEndpointToSpec(openapi, ep); err != nil {
+			d.config.Logger.Warn("openswag: endpoint skipped", "error", err)
+			continue
+		}
+		endpoints = append(endpoints, ep)
+	}
+
+	d.addWebhooksToSpec(openapi)
+
+	if d.config.OperationIDCollisions == OperationIDError {
+		for _, err := range d.operationIDCollisions() {
+			d.config.Logger.Warn("openswag: duplicate operationId", "error", err)
+		}
+	}
+
+	// Register component schemas referenced by the operations
+	for name, def := range d.definitions {
+		openapi.AddSchema(name, convertSchema(def))
 	}
+	d.definitions = nil
 
 	// Add predefined security schemes if any endpoint uses security
-	d.addSecuritySchemes(openapi)
+	d.addSecuritySchemes(openapi, endpoints)
 
-	d.openapi = openapi
-	return openapi
+	// Patches may target internal endpoints, so only the full spec reports failures
+	d.applyPatches(openapi, includeInternal)
+
+	*cache = openapi
+	if err := d.applyMutators(openapi); err != nil {
+		*errCache = err
+		d.config.Logger.Warn("openswag: spec build aborted", "error", err, "internal", includeInternal)
+		return openapi, err
+	}
+
+	d.config.Logger.Info("openswag: spec rebuilt", "endpoints", len(endpoints), "paths", len(openapi.Paths), "internal", includeInternal)
+	return openapi, nil
 }
 
 // addSecuritySchemes adds predefined security schemes based on endpoint usage
-func (d *Docs) addSecuritySchemes(openapi *spec.OpenAPI) {
+func (d *Docs) addSecuritySchemes(openapi *spec.OpenAPI, endpoints []Endpoint) {
 	usedSchemes := make(map[string]bool)
 
-	// Collect all used security schemes from endpoints
-	for _, ep := range d.endpoints {
-		for _, sec := range ep.Security {
+	// Collect all used security schemes from the global default and endpoints
+	for _, sec := range d.config.Security {
+		usedSchemes[sec] = true
+	}
+	for _, ep := range endpoints {
+		for _, sec := range d.endpointSecurity(ep) {
 			usedSchemes[sec] = true
 		}
 	}
@@ -213,33 +469,28 @@ func (d *Docs) addSecuritySchemes(openapi *spec.OpenAPI) {
 	}
 }
 
-func (d *Docs) addEndpointToSpec(openapi *spec.OpenAPI, ep Endpoint) {
-	pathItem := openapi.Paths[ep.Path]
+func (d *Docs) addEndpointToSpec(openapi *spec.OpenAPI, ep Endpoint) error {
+	if !spec.IsMethod(ep.Method) {
+		return fmt.Errorf("%s: unknown HTTP method %q", ep.Path, ep.Method)
+	}
+
+	path := openAPIPath(ep.Path)
+	pathItem := openapi.Paths[path]
 	if pathItem == nil {
 		pathItem = spec.NewPathItem()
 	}
 
 	operation := d.buildOperation(ep)
 
-	method := strings.ToUpper(ep.Method)
-	switch method {
-	case "GET":
-		pathItem.SetGet(operation)
-	case "POST":
-		pathItem.SetPost(operation)
-	case "PUT":
-		pathItem.SetPut(operation)
-	case "PATCH":
-		pathItem.SetPatch(operation)
-	case "DELETE":
-		pathItem.SetDelete(operation)
-	}
+	pathItem.SetOperation(ep.Method, operation)
 
-	openapi.AddPath(ep.Path, pathItem)
+	openapi.AddPath(path, pathItem)
+	return nil
 }
 
 func (d *Docs) buildOperation(ep Endpoint) *spec.Operation {
 	op := spec.NewOperation(ep.Summary).
+		WithOperationID(operationID(ep)).
 		WithDescription(ep.Description).
 		WithTags(ep.Tags...).
 		SetDeprecated(ep.Deprecated)
@@ -248,13 +499,23 @@ func (d *Docs) buildOperation(ep Endpoint) *spec.Operation {
 	for _, param := range ep.Parameters {
 		p := spec.NewParameter(param.Name, param.In).
 			WithDescription(param.Description).
-			SetRequired(param.Required)
+			SetRequired(param.Required).
+			SetDeprecated(param.Deprecated)
 
 		if param.Schema != nil {
 			p.WithSchema(param.Schema)
 		} else {
 			p.WithSchema(spec.NewSchema("string"))
 		}
+		if param.Example != nil {
+			p.WithExample(param.Example)
+		} else {
+			p.Examples = buildExamples(param.Examples)
+		}
+		p.Style = param.Style
+		p.Explode = param.Explode
+		p.AllowEmptyValue = param.AllowEmptyValue
+		p.AllowReserved = param.AllowReserved
 
 		op.AddParameter(p)
 	}
@@ -292,41 +553,141 @@ func (d *Docs) buildOperation(ep Endpoint) *spec.Operation {
 	if ep.RequestBody != nil {
 		contentType := ep.RequestBody.ContentType
 		if contentType == "" {
-			contentType = "application/json"
+			contentType = detectContentType(ep.RequestBody.Schema)
 		}
 
 		var s *spec.Schema
 		if ep.RequestBody.Schema != nil {
-			schemaResult := schema.FromType(ep.RequestBody.Schema)
-			s = convertSchema(schemaResult)
+			s = d.schemaForMedia(ep.RequestBody.Schema, contentType)
 		}
 
 		rb := spec.NewRequestBody(ep.RequestBody.Description, ep.RequestBody.Required).
-			WithJSONContent(s)
+			WithContent(contentType, s)
+		if contentType == ContentTypeURLEncoded {
+			rb.Content[contentType].Encoding = formEncoding(s)
+		}
+		rb.Content[contentType].Encoding = partEncoding(rb.Content[contentType].Encoding, ep.RequestBody.Encoding)
+		rb.Content[contentType].Examples = buildExamples(ep.RequestBody.Examples)
 		op.WithRequestBody(rb)
 	}
 
 	// Build responses
+	preferVariants := make(map[string]interface{})
 	for code, resp := range ep.Responses {
-		r := spec.NewResponse(resp.Description)
+		r := spec.NewResponse(d.responseDescription(code, resp.Description))
+
+		contentType := resp.ContentType
+		if contentType == "" {
+			contentType = ContentTypeJSON
+		}
+		switch {
+		case resp.Streaming:
+			r.WithContent(contentType, d.sseEvents(streamSchema(), resp.Events))
+		case resp.Schema != nil:
+			r.WithContent(contentType, d.schemaForMedia(resp.Schema, contentType))
+		case contentType != ContentTypeJSON:
+			r.WithContent(contentType, binarySchema())
+		}
+		if examples := buildExamples(resp.Examples); examples != nil {
+			if r.Content[contentType] == nil {
+				r.WithContent(contentType, nil)
+			}
+			r.Content[contentType].Examples = examples
+		}
+
+		// Additional media types, each with its own schema
+		for mediaType, body := range resp.Content {
+			if body == nil {
+				r.WithContent(mediaType, binarySchema())
+				continue
+			}
+			r.WithContent(mediaType, d.schemaForMedia(body, mediaType))
+		}
 
-		if resp.Schema != nil {
-			schemaResult := schema.FromType(resp.Schema)
-			s := convertSchema(schemaResult)
-			r.WithContent("application/json", s)
+		for name, link := range resp.Links {
+			r.AddLink(name, buildLink(link))
+		}
+
+		for name, h := range resp.Headers {
+			headerSchema := h.Schema
+			if headerSchema == nil {
+				headerSchema = spec.NewSchema("string")
+			}
+			r.AddHeader(name, &spec.Header{Description: h.Description, Schema: headerSchema, Example: h.Example})
 		}
 
 		op.AddResponse(intToString(code), r)
+		if len(resp.Prefer) > 0 {
+			preferVariants[intToString(code)] = d.preferVariants(resp)
+		}
 	}
+	applyPrefer(op, preferVariants)
+
+	d.applyValidationErrors(ep, op)
+	d.applyResponseWrappers(ep, op)
+
+	if d.config.CaptureExamples {
+		captureExample(ep, op)
+	}
+	d.warnMissingSchemas(ep, op)
+
+	d.applyDeprecation(ep, op)
+	applyRateLimit(ep, op)
+	applySLA(ep, op)
+	applyTimeout(ep, op)
+	applyIdempotency(ep, op)
+	applyCacheControl(ep, op)
 
 	// Build security
-	for _, secName := range ep.Security {
-		op.WithSecurity(spec.SecurityRequirement{secName: {}})
+	if ep.Public {
+		op.SetPublic()
+	}
+	// Each scheme is an alternative requirement; WithSecurity replaces the
+	// list, so it is set once
+	if schemes := d.endpointSecurity(ep); len(schemes) > 0 {
+		reqs := make([]spec.SecurityRequirement, len(schemes))
+		for i, secName := range schemes {
+			reqs[i] = spec.SecurityRequirement{secName: {}}
+		}
+		op.WithSecurity(reqs...)
+	}
+
+	// Build per-operation servers
+	for _, srv := range ep.Servers {
+		op.AddServer(spec.NewServer(srv.URL).WithDescription(srv.Description))
+	}
+
+	// Build callbacks
+	for expression, cb := range ep.Callbacks {
+		method := cb.Method
+		if method == "" {
+			method = "POST"
+		}
+		pathItem := spec.NewPathItem().SetOperation(method, d.buildOperation(cb))
+		op.AddCallback(expression, &spec.Callback{expression: pathItem})
 	}
 
 	return op
 }
 
+// buildLink converts a Link into its spec representation
+func buildLink(link Link) *spec.Link {
+	l := &spec.Link{
+		OperationID: link.OperationID,
+		Description: link.Description,
+		RequestBody: link.RequestBody,
+	}
+
+	if len(link.Parameters) > 0 {
+		l.Parameters = make(map[string]any, len(link.Parameters))
+		for name, expr := range link.Parameters {
+			l.Parameters[name] = expr
+		}
+	}
+
+	return l
+}
+
 // buildParamsFromStruct extracts parameters from a struct using reflection
 func (d *Docs) buildParamsFromStruct(v interface{}, location string) []*spec.Parameter {
 	var params []*spec.Parameter
@@ -395,6 +756,10 @@ func (d *Docs) buildParamsFromStruct(v interface{}, location string) []*spec.Par
 			p.SetRequired(true)
 		}
 
+		if schema.IsDeprecated(field) {
+			p.SetDeprecated(true)
+		}
+
 		// Check for example tag
 		if example := field.Tag.Get("example"); example != "" {
 			p.WithExample(example)
@@ -406,7 +771,9 @@ func (d *Docs) buildParamsFromStruct(v interface{}, location string) []*spec.Par
 	return params
 }
 
-// extractPathParams extracts parameter names from path like /users/:id or /users/{id}
+// extractPathParams extracts parameter names from path like /users/:id or
+// /users/{id}, including several templated parameters in one segment such as
+// /tiles/{z}/{x},{y}
 func extractPathParams(path string) []string {
 	var params []string
 	parts := strings.Split(path, "/")
@@ -414,14 +781,31 @@ func extractPathParams(path string) []string {
 	for _, part := range parts {
 		if strings.HasPrefix(part, ":") {
 			params = append(params, strings.TrimPrefix(part, ":"))
-		} else if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
-			params = append(params, strings.Trim(part, "{}"))
+			continue
+		}
+		for _, match := range pathTemplateParam.FindAllStringSubmatch(part, -1) {
+			params = append(params, match[1])
 		}
 	}
 
 	return params
 }
 
+// pathTemplateParam matches a {name} expression in a path segment
+var pathTemplateParam = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// openAPIPath rewrites Gin/Echo-style :param segments to the {param} form
+// required by OpenAPI, e.g. /users/:id -> /users/{id}
+func openAPIPath(path string) string {
+	parts := strings.Split(path, "/")
+	for i, part := range parts {
+		if name, ok := pathParamName(part); ok {
+			parts[i] = "{" + name + "}"
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
 // hasParam checks if a parameter with the given name already exists
 func hasParam(params []*spec.Parameter, name string) bool {
 	for _, p := range params {
@@ -432,16 +816,44 @@ func hasParam(params []*spec.Parameter, name string) bool {
 	return false
 }
 
+// schemaFor converts a Go value's type to a spec schema, remembering the
+// component schemas it references (e.g. embedded base types composed via
+// allOf) so buildSpec can register them. Callers must hold d.mu.
+func (d *Docs) schemaFor(v interface{}) *spec.Schema {
+	return d.schemaForMedia(v, "")
+}
+
+// schemaForMedia is schemaFor for a body of the given media type, whose
+// fields are named by the matching struct tags (xml, form or json)
+func (d *Docs) schemaForMedia(v interface{}, contentType string) *spec.Schema {
+	// Embedded structs become component refs only while a build collects them
+	s := schema.FromTypeWithOptions(v, schema.Options{
+		FieldNaming:        d.config.FieldNaming,
+		PreserveFieldOrder: d.config.PreserveFieldOrder,
+		MaxDepth:           d.config.MaxSchemaDepth,
+		ContentType:        contentType,
+		ComposeEmbedded:    d.definitions != nil,
+	})
+	if d.definitions != nil {
+		schema.CollectDefinitions(s, d.definitions)
+	}
+	return convertSchema(s)
+}
+
 func convertSchema(s *schema.Schema) *spec.Schema {
 	if s == nil {
 		return nil
 	}
 
 	result := &spec.Schema{
+		Ref:         s.Ref,
+		Title:       s.Title,
 		Type:        s.Type,
 		Format:      s.Format,
 		Description: s.Description,
 		Example:     s.Example,
+		Examples:    s.Examples,
+		Comment:     s.Comment,
 		Default:     s.Default,
 		Enum:        s.Enum,
 		Required:    s.Required,
@@ -450,12 +862,28 @@ func convertSchema(s *schema.Schema) *spec.Schema {
 		Maximum:     s.Maximum,
 		MinLength:   s.MinLength,
 		MaxLength:   s.MaxLength,
+		Deprecated:  s.Deprecated,
+		ReadOnly:    s.ReadOnly,
+		WriteOnly:   s.WriteOnly,
+	}
+
+	for key, value := range s.Extensions {
+		result.WithExtension(key, value)
+	}
+
+	// Keep "required" out of the output when no field is required
+	if len(result.Required) == 0 {
+		result.Required = nil
 	}
 
 	if s.Items != nil {
 		result.Items = convertSchema(s.Items)
 	}
 
+	for _, sub := range s.AllOf {
+		result.AllOf = append(result.AllOf, convertSchema(sub))
+	}
+
 	if len(s.Properties) > 0 {
 		result.Properties = make(map[string]*spec.Schema)
 		for k, v := range s.Properties {
@@ -466,6 +894,21 @@ func convertSchema(s *schema.Schema) *spec.Schema {
 	return result
 }
 
+// responseDescription falls back to a status-based description, since
+// OpenAPI requires every response to have one
+func (d *Docs) responseDescription(code int, description string) string {
+	if description != "" {
+		return description
+	}
+	if desc, ok := d.config.ResponseDescriptions[code]; ok && desc != "" {
+		return desc
+	}
+	if text := http.StatusText(code); text != "" {
+		return text
+	}
+	return "Response"
+}
+
 func intToString(n int) string {
 	if n == 0 {
 		return "0"
@@ -480,6 +923,22 @@ func intToString(n int) string {
 
 // SpecJSON returns the OpenAPI spec as JSON
 func (d *Docs) SpecJSON() ([]byte, error) {
-	openapi := d.BuildSpec()
-	return json.MarshalIndent(openapi, "", "  ")
+	openapi, err := d.buildSpec(d.config.IncludeInternal)
+	if err != nil {
+		return nil, err
+	}
+	return marshalSpec(openapi, d.prettyPrint())
+}
+
+// prettyPrint reports whether the spec is indented (Config.PrettyPrint, default true)
+func (d *Docs) prettyPrint() bool {
+	return d.config.PrettyPrint == nil || *d.config.PrettyPrint
+}
+
+// marshalSpec encodes v as indented or compact JSON
+func marshalSpec(v interface{}, pretty bool) ([]byte, error) {
+	if pretty {
+		return json.MarshalIndent(v, "", "  ")
+	}
+	return json.Marshal(v)
 }
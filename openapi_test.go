@@ -0,0 +1,1659 @@
+package openswag
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andrianprasetya/open-swag-go/pkg/spec"
+)
+
+func TestBuildSpec_OptionalFieldsOmitRequired(t *testing.T) {
+	type Filter struct {
+		Name  string `json:"name"`
+		Limit int    `json:"limit"`
+	}
+
+	docs := New(Config{Info: Info{Title: "Test", Version: "1.0.0"}})
+	docs.Add(Endpoint{
+		Method:      "POST",
+		Path:        "/search",
+		RequestBody: &RequestBody{Schema: Filter{}},
+	})
+
+	s := docs.BuildSpec().Paths["/search"].Post.RequestBody.Content["application/json"].Schema
+	if s.Required != nil {
+		t.Errorf("expected nil required, got %v", s.Required)
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := result["required"]; ok {
+		t.Errorf("expected 'required' key to be omitted, got %s", string(data))
+	}
+}
+
+func TestBuildSpec_Callbacks(t *testing.T) {
+	type Event struct {
+		ID string `json:"id"`
+	}
+
+	expr := "{$request.body#/callbackUrl}"
+	docs := New(Config{Info: Info{Title: "Test", Version: "1.0.0"}})
+	docs.Add(Endpoint{
+		Method: "POST",
+		Path:   "/subscriptions",
+		Callbacks: map[string]Endpoint{
+			expr: {
+				Summary:     "Event notification",
+				RequestBody: &RequestBody{Schema: Event{}},
+				Responses:   map[int]Response{200: {Description: "Received"}},
+			},
+		},
+	})
+
+	op := docs.BuildSpec().Paths["/subscriptions"].Post
+	cb, ok := op.Callbacks[expr]
+	if !ok {
+		t.Fatalf("expected callback %q", expr)
+	}
+
+	item := (*cb)[expr]
+	if item == nil || item.Post == nil {
+		t.Fatal("expected callback POST operation")
+	}
+	if item.Post.RequestBody == nil {
+		t.Error("expected callback request body")
+	}
+	if _, ok := item.Post.Responses["200"]; !ok {
+		t.Error("expected callback 200 response")
+	}
+}
+
+func TestBuildSpec_CaptureExamples(t *testing.T) {
+	type User struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+
+	docs := New(Config{Info: Info{Title: "Test", Version: "1.0.0"}, CaptureExamples: true})
+	docs.Add(Endpoint{
+		Method:    "GET",
+		Path:      "/users/{id}",
+		Responses: map[int]Response{200: {Description: "OK", Schema: User{}}},
+		Handler: func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(User{ID: "1", Name: "Jane"})
+		},
+	})
+
+	example := docs.BuildSpec().Paths["/users/{id}"].Get.Responses["200"].Content["application/json"].Example
+	m, ok := example.(map[string]interface{})
+	if !ok || m["name"] != "Jane" {
+		t.Errorf("expected captured example, got %v", example)
+	}
+}
+
+func TestEndpointBuilder(t *testing.T) {
+	type User struct {
+		ID string `json:"id"`
+	}
+
+	ep := GET("/users/{id}").
+		Summary("Get user").
+		Tag("Users").
+		Param(PathParam("id", "User ID")).
+		Response(200, "OK", User{}).
+		Security(SecurityBearerAuth).
+		Deprecated().
+		Build()
+
+	if ep.Method != "GET" || ep.Path != "/users/{id}" || ep.Summary != "Get user" {
+		t.Errorf("unexpected endpoint: %+v", ep)
+	}
+	if len(ep.Tags) != 1 || len(ep.Parameters) != 1 || len(ep.Security) != 1 || !ep.Deprecated {
+		t.Errorf("unexpected endpoint: %+v", ep)
+	}
+	if ep.Responses[200].Description != "OK" {
+		t.Errorf("expected 200 response, got %+v", ep.Responses)
+	}
+}
+
+func TestBuildSpec_URLEncodedBody(t *testing.T) {
+	type LoginForm struct {
+		Username string   `form:"username"`
+		Password string   `form:"password"`
+		Scopes   []string `form:"scope"`
+	}
+	type JSONBody struct {
+		Name string `json:"name"`
+	}
+
+	docs := New(Config{Info: Info{Title: "Test", Version: "1.0.0"}})
+	docs.AddAll(
+		Endpoint{Method: "POST", Path: "/login", RequestBody: &RequestBody{Schema: LoginForm{}}},
+		Endpoint{Method: "POST", Path: "/token", RequestBody: URLEncodedBody(JSONBody{})},
+		Endpoint{Method: "POST", Path: "/users", RequestBody: &RequestBody{Schema: JSONBody{}}},
+	)
+	openapi := docs.BuildSpec()
+
+	login := openapi.Paths["/login"].Post.RequestBody.Content[ContentTypeURLEncoded]
+	if login == nil {
+		t.Fatal("expected form-tagged struct to be urlencoded")
+	}
+	if enc := login.Encoding["scope"]; enc == nil || !enc.Explode {
+		t.Errorf("expected exploded form encoding for array field, got %v", login.Encoding)
+	}
+	if openapi.Paths["/token"].Post.RequestBody.Content[ContentTypeURLEncoded] == nil {
+		t.Error("expected explicit URLEncodedBody to win")
+	}
+	if openapi.Paths["/users"].Post.RequestBody.Content[ContentTypeJSON] == nil {
+		t.Error("expected json-tagged struct to stay JSON")
+	}
+}
+
+func TestBuildSpec_MultipartPartEncoding(t *testing.T) {
+	type Upload struct {
+		Metadata map[string]string `json:"metadata"`
+		File     string            `json:"file" format:"binary"`
+	}
+
+	docs := New(Config{Info: Info{Title: "Test", Version: "1.0.0"}})
+	docs.Add(Endpoint{
+		Method: "POST",
+		Path:   "/uploads",
+		RequestBody: FormBody(Upload{}).
+			WithPartEncoding("metadata", PartEncoding{ContentType: ContentTypeJSON}).
+			WithPartEncoding("file", PartEncoding{
+				ContentType: "image/png, image/jpeg",
+				Headers:     map[string]string{"X-Checksum": "SHA-256 of the file"},
+			}),
+	})
+
+	media := docs.BuildSpec().Paths["/uploads"].Post.RequestBody.Content[ContentTypeMultipart]
+	if media == nil {
+		t.Fatal("expected a multipart/form-data body")
+	}
+	if enc := media.Encoding["metadata"]; enc == nil || enc.ContentType != ContentTypeJSON {
+		t.Errorf("expected JSON metadata part, got %+v", enc)
+	}
+	enc := media.Encoding["file"]
+	if enc == nil || enc.ContentType != "image/png, image/jpeg" || enc.Headers["X-Checksum"] == nil {
+		t.Errorf("expected image file part with checksum header, got %+v", enc)
+	}
+}
+
+func TestBuildSpec_Sunset(t *testing.T) {
+	docs := New(Config{Info: Info{Title: "Test", Version: "1.0.0"}})
+	docs.AddAll(
+		Endpoint{
+			Method:      "GET",
+			Path:        "/v1/users",
+			Deprecation: Deprecated("Use /v2/users", "2026-12-31T00:00:00Z"),
+			Responses:   map[int]Response{200: {Description: "OK"}},
+		},
+		Endpoint{Method: "GET", Path: "/v1/orders", SunsetDate: "next year"},
+		// A bare date is not an HTTP-date (RFC 8594)
+		Endpoint{Method: "GET", Path: "/v1/invoices", SunsetDate: "2027-01-01"},
+	)
+
+	op := docs.BuildSpec().Paths["/v1/users"].Get
+	if !op.Deprecated {
+		t.Error("expected operation to be deprecated")
+	}
+	if op.Extensions["x-sunset"] != "2026-12-31T00:00:00Z" {
+		t.Errorf("expected x-sunset extension, got %v", op.Extensions)
+	}
+	if op.Responses["200"].Headers["Sunset"] == nil {
+		t.Error("expected Sunset response header")
+	}
+
+	errs := docs.Validate()
+	if len(errs) != 2 {
+		t.Errorf("expected 2 validation errors for invalid sunset dates, got %v", errs)
+	}
+}
+
+func TestBuildSpec_FileDownloadResponse(t *testing.T) {
+	docs := New(Config{Info: Info{Title: "Test", Version: "1.0.0"}})
+	docs.Add(Endpoint{
+		Method: "GET",
+		Path:   "/reports/{id}",
+		Responses: map[int]Response{
+			200: FileDownloadResponse("Report", "application/pdf"),
+			204: NoContentResponse(""),
+		},
+	})
+
+	op := docs.BuildSpec().Paths["/reports/{id}"].Get
+	media, ok := op.Responses["200"].Content["application/pdf"]
+	if !ok {
+		t.Fatalf("expected application/pdf content, got %v", op.Responses["200"].Content)
+	}
+	if media.Schema.Type != "string" || media.Schema.Format != "binary" {
+		t.Errorf("expected binary string schema, got %+v", media.Schema)
+	}
+	if len(op.Responses["204"].Content) != 0 {
+		t.Error("expected no content for 204 response")
+	}
+}
+
+func TestBuildSpec_TagSecurity(t *testing.T) {
+	docs := New(Config{
+		Info:        Info{Title: "Test", Version: "1.0.0"},
+		TagSecurity: map[string][]string{"Admin": {SecurityBearerAuth}},
+	})
+	docs.AddAll(
+		Endpoint{Method: "GET", Path: "/admin/users", Tags: []string{"Admin"}},
+		Endpoint{Method: "GET", Path: "/admin/stats", Tags: []string{"Admin"}, Security: []string{SecurityApiKey}},
+	)
+
+	openapi := docs.BuildSpec()
+	users := openapi.Paths["/admin/users"].Get
+	if len(users.Security) != 1 || users.Security[0][SecurityBearerAuth] == nil {
+		t.Errorf("expected tag default security, got %v", users.Security)
+	}
+	stats := openapi.Paths["/admin/stats"].Get
+	if len(stats.Security) != 1 || stats.Security[0][SecurityApiKey] == nil {
+		t.Errorf("expected endpoint security to win, got %v", stats.Security)
+	}
+	if openapi.Components.SecuritySchemes[SecurityBearerAuth] == nil {
+		t.Error("expected bearerAuth scheme to be registered")
+	}
+}
+
+func TestBuildSpec_TagSecurityCombined(t *testing.T) {
+	docs := New(Config{
+		Info: Info{Title: "Test", Version: "1.0.0"},
+		TagSecurity: map[string][]string{
+			"Admin":   {SecurityBearerAuth},
+			"Billing": {SecurityApiKey},
+		},
+	})
+	docs.Add(Endpoint{Method: "GET", Path: "/admin/invoices", Tags: []string{"Admin", "Billing"}})
+
+	security := docs.BuildSpec().Paths["/admin/invoices"].Get.Security
+	if len(security) != 2 || security[0][SecurityBearerAuth] == nil || security[1][SecurityApiKey] == nil {
+		t.Errorf("expected the schemes of both tags, got %v", security)
+	}
+}
+
+func TestBuildSpec_GlobalSecurityWithPublicOverride(t *testing.T) {
+	docs := New(Config{
+		Info:     Info{Title: "Test", Version: "1.0.0"},
+		Security: []string{SecurityBearerAuth},
+	})
+	docs.AddAll(
+		Endpoint{Method: "GET", Path: "/users"},
+		Endpoint{Method: "GET", Path: "/health", Public: true},
+	)
+
+	openapi := docs.BuildSpec()
+	if len(openapi.Security) != 1 || openapi.Security[0][SecurityBearerAuth] == nil {
+		t.Errorf("expected global bearerAuth requirement, got %v", openapi.Security)
+	}
+	if openapi.Components.SecuritySchemes[SecurityBearerAuth] == nil {
+		t.Error("expected bearerAuth scheme to be registered")
+	}
+
+	users, _ := json.Marshal(openapi.Paths["/users"].Get)
+	if strings.Contains(string(users), `"security"`) {
+		t.Errorf("expected /users to inherit global security, got %s", users)
+	}
+	health, _ := json.Marshal(openapi.Paths["/health"].Get)
+	if !strings.Contains(string(health), `"security":[]`) {
+		t.Errorf("expected empty security array for public endpoint, got %s", health)
+	}
+}
+
+func TestPatchSchema(t *testing.T) {
+	type CreateUser struct {
+		Email string `json:"email"`
+	}
+
+	docs := New(Config{Info: Info{Title: "Test", Version: "1.0.0"}})
+	docs.Add(Endpoint{Method: "POST", Path: "/users", RequestBody: &RequestBody{Schema: CreateUser{}}})
+	docs.PatchSchema("/paths/~1users/post/requestBody", func(s *spec.Schema) {
+		s.Description = "New user"
+	})
+	docs.PatchSchema("/paths/~1users/post/requestBody/content/application~1json/schema/properties/email", func(s *spec.Schema) {
+		s.Pattern = "^.+@.+$"
+	})
+	docs.PatchSchema("/paths/~1orders/get", func(s *spec.Schema) {})
+
+	body := docs.BuildSpec().Paths["/users"].Post.RequestBody.Content["application/json"].Schema
+	if body.Description != "New user" {
+		t.Errorf("expected patched description, got %q", body.Description)
+	}
+	if body.Properties["email"].Pattern != "^.+@.+$" {
+		t.Errorf("expected patched pattern, got %q", body.Properties["email"].Pattern)
+	}
+
+	if errs := docs.Validate(); len(errs) != 1 {
+		t.Errorf("expected 1 unresolved pointer error, got %v", errs)
+	}
+}
+
+func TestBuildSpec_OptionalBody(t *testing.T) {
+	type UpdateUser struct {
+		Name string `json:"name"`
+	}
+
+	docs := New(Config{Info: Info{Title: "Test", Version: "1.0.0"}})
+	docs.Add(Endpoint{Method: "POST", Path: "/users", RequestBody: Body(UpdateUser{})})
+	docs.Add(Endpoint{Method: "PATCH", Path: "/users/{id}", RequestBody: OptionalBody(UpdateUser{})})
+
+	openapi := docs.BuildSpec()
+	if !openapi.Paths["/users"].Post.RequestBody.Required {
+		t.Error("expected Body to be required")
+	}
+	patch := openapi.Paths["/users/{id}"].Patch.RequestBody
+	if patch.Required {
+		t.Error("expected OptionalBody not to be required")
+	}
+	if patch.Content["application/json"] == nil {
+		t.Errorf("expected JSON content, got %v", patch.Content)
+	}
+}
+
+func TestBuildSpec_SSEEvents(t *testing.T) {
+	type PriceTick struct {
+		Symbol string  `json:"symbol"`
+		Price  float64 `json:"price"`
+	}
+
+	docs := New(Config{Info: Info{Title: "Test", Version: "1.0.0"}})
+	docs.Add(Endpoint{
+		Method: "GET",
+		Path:   "/prices/stream",
+		Responses: map[int]Response{200: SSEResponse("Price updates", map[string]interface{}{
+			"tick":      PriceTick{},
+			"heartbeat": nil,
+		})},
+	})
+
+	media := docs.BuildSpec().Paths["/prices/stream"].Get.Responses["200"].Content["text/event-stream"]
+	if media == nil || media.Schema.Extensions["x-streaming"] != true {
+		t.Fatalf("expected a streaming text/event-stream body, got %+v", media)
+	}
+	events, ok := media.Schema.Extensions["x-sse-events"].(map[string]*spec.Schema)
+	if !ok || len(events) != 2 {
+		t.Fatalf("expected the x-sse-events catalog, got %v", media.Schema.Extensions)
+	}
+	if events["tick"].Properties["price"] == nil {
+		t.Errorf("expected the tick payload schema, got %+v", events["tick"])
+	}
+
+	data, _ := docs.SpecJSON()
+	if !strings.Contains(string(data), `"x-sse-events"`) {
+		t.Errorf("expected x-sse-events in the spec, got %s", data)
+	}
+}
+
+func TestNew_UnsupportedOpenAPIVersion(t *testing.T) {
+	config := Config{Info: Info{Title: "Test", Version: "1.0.0"}, OpenAPIVersion: "3.2"}
+
+	docs, err := NewE(config)
+	if docs != nil || err == nil || !strings.Contains(err.Error(), `"3.2"`) || !strings.Contains(err.Error(), "3.0.3, 3.0.4, 3.1.0") {
+		t.Errorf("expected the supported versions in the error, got %v", err)
+	}
+
+	func() {
+		defer func() {
+			if r := recover(); r == nil || !strings.Contains(fmt.Sprint(r), `"3.2"`) {
+				t.Errorf("expected New to panic on the version, got %v", r)
+			}
+		}()
+		New(config)
+	}()
+
+	config.OpenAPIVersion = "3.0.3"
+	if openapi := New(config).BuildSpec(); openapi.OpenAPI != "3.0.3" {
+		t.Errorf("expected a 3.0.3 spec, got %s", openapi.OpenAPI)
+	}
+}
+
+func TestUse(t *testing.T) {
+	docs := New(Config{Info: Info{Title: "Test", Version: "1.0.0"}})
+	docs.Add(Endpoint{Method: "GET", Path: "/users", Responses: map[int]Response{200: {Schema: AdminUser{}}}})
+
+	var order []string
+	docs.Use(func(openapi *spec.OpenAPI) error {
+		order = append(order, "first")
+		if openapi.Components == nil || openapi.Components.Schemas["BaseUser"] == nil {
+			t.Error("expected mutators to see the component schemas")
+		}
+		openapi.Info.Description = "Owned by the platform team"
+		return nil
+	})
+	docs.Use(func(openapi *spec.OpenAPI) error {
+		order = append(order, "second")
+		openapi.Info.Description += "."
+		return nil
+	})
+
+	if got := docs.BuildSpec().Info.Description; got != "Owned by the platform team." {
+		t.Errorf("expected mutators to run in order, got %q", got)
+	}
+	if strings.Join(order, ",") != "first,second" {
+		t.Errorf("unexpected mutator order %v", order)
+	}
+
+	logger := &warnRecorder{}
+	docs.config.Logger = logger
+	docs.Use(func(*spec.OpenAPI) error { return errors.New("missing owner") })
+	if openapi := docs.BuildSpec(); openapi == nil || openapi.Paths["/users"] == nil {
+		t.Errorf("expected BuildSpec to still return the spec, got %+v", openapi)
+	}
+	if openapi, err := docs.BuildSpecE(); openapi != nil || err == nil || !strings.Contains(err.Error(), "missing owner") {
+		t.Errorf("expected the mutator error from BuildSpecE, got %v", err)
+	}
+	if _, err := docs.SpecJSON(); err == nil || !strings.Contains(err.Error(), "missing owner") {
+		t.Errorf("expected the mutator error from SpecJSON, got %v", err)
+	}
+	if len(logger.warnings) != 1 {
+		t.Errorf("expected the failed build to be memoized and logged once, got %v", logger.warnings)
+	}
+	if errs := docs.Validate(); len(errs) != 1 {
+		t.Errorf("expected the mutator error from Validate, got %v", errs)
+	}
+}
+
+func TestBuildSpec_DefaultResponseDescription(t *testing.T) {
+	docs := New(Config{
+		Info:                 Info{Title: "Test", Version: "1.0.0"},
+		ResponseDescriptions: map[int]string{422: "Validation failed"},
+	})
+	docs.Add(Endpoint{
+		Method: "GET",
+		Path:   "/users",
+		Responses: map[int]Response{
+			200: {Description: "Users"},
+			404: {},
+			422: {},
+		},
+	})
+
+	responses := docs.BuildSpec().Paths["/users"].Get.Responses
+	for code, want := range map[string]string{"200": "Users", "404": "Not Found", "422": "Validation failed"} {
+		if got := responses[code].Description; got != want {
+			t.Errorf("response %s: expected description %q, got %q", code, want, got)
+		}
+	}
+}
+
+func TestBuildSpec_StreamResponse(t *testing.T) {
+	docs := New(Config{Info: Info{Title: "Test", Version: "1.0.0"}})
+	docs.Add(Endpoint{
+		Method:    "GET",
+		Path:      "/events",
+		Responses: map[int]Response{200: StreamResponse("Event stream", "text/event-stream")},
+	})
+
+	media := docs.BuildSpec().Paths["/events"].Get.Responses["200"].Content["text/event-stream"]
+	if media == nil || media.Schema.Type != "string" || media.Schema.Extensions["x-streaming"] != true {
+		t.Errorf("expected streaming string schema, got %+v", media)
+	}
+}
+
+func TestPaginationAndSortParams(t *testing.T) {
+	docs := New(Config{Info: Info{Title: "Test", Version: "1.0.0"}})
+	docs.Add(Endpoint{
+		Method:     "GET",
+		Path:       "/users",
+		Parameters: append(PaginationParams(), SortParams("name", "created_at")...),
+	})
+
+	params := docs.BuildSpec().Paths["/users"].Get.Parameters
+	if len(params) != 4 {
+		t.Fatalf("expected 4 parameters, got %d", len(params))
+	}
+	if params[0].Name != "page" || params[0].Schema.Type != "integer" || params[0].Schema.Default != 1 {
+		t.Errorf("unexpected page parameter: %+v", params[0].Schema)
+	}
+	if sort := params[2].Schema; len(sort.Enum) != 2 || sort.Enum[1] != "created_at" {
+		t.Errorf("expected sort enum of fields, got %v", sort.Enum)
+	}
+}
+
+func TestValidate_PathParamMismatch(t *testing.T) {
+	type OrderPath struct {
+		OrderID string `path:"order_id"`
+	}
+
+	docs := New(Config{Info: Info{Title: "Test", Version: "1.0.0"}})
+	docs.AddAll(
+		Endpoint{Method: "GET", Path: "/users/{userId}", Parameters: []Parameter{PathParam("id", "User ID")}},
+		Endpoint{Method: "GET", Path: "/orders/:id", PathParams: OrderPath{}},
+		Endpoint{Method: "GET", Path: "/teams/{id}", Parameters: []Parameter{PathParam("id", "Team ID")}},
+	)
+
+	errs := docs.Validate()
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 path parameter errors, got %v", errs)
+	}
+	if !strings.Contains(errs[0].Error(), `"id"`) || !strings.Contains(errs[1].Error(), `"order_id"`) {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+}
+
+func TestBuildSpec_NormalizesColonPaths(t *testing.T) {
+	docs := New(Config{Info: Info{Title: "Test", Version: "1.0.0"}})
+	docs.AddAll(
+		Endpoint{Method: "GET", Path: "/users/:id"},
+		Endpoint{Method: "DELETE", Path: "/users/{id}"},
+		Endpoint{Method: "GET", Path: "/users/:id/posts/{postId}"},
+	)
+
+	paths := docs.BuildSpec().Paths
+	if _, ok := paths["/users/:id"]; ok {
+		t.Error("expected colon path to be normalized")
+	}
+	item := paths["/users/{id}"]
+	if item == nil || item.Get == nil || item.Delete == nil {
+		t.Fatalf("expected GET and DELETE under /users/{id}, got %+v", item)
+	}
+	if _, ok := paths["/users/{id}/posts/{postId}"]; !ok {
+		t.Error("expected mixed-style path to be normalized")
+	}
+}
+
+type BaseUser struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type AdminUser struct {
+	BaseUser
+	Permissions []string `json:"permissions"`
+}
+
+func TestBuildSpec_EmbeddedStructAllOf(t *testing.T) {
+	docs := New(Config{Info: Info{Title: "Test", Version: "1.0.0"}})
+	docs.Add(Endpoint{
+		Method:    "GET",
+		Path:      "/admins/{id}",
+		Responses: map[int]Response{200: {Description: "OK", Schema: AdminUser{}}},
+	})
+
+	openapi := docs.BuildSpec()
+	s := openapi.Paths["/admins/{id}"].Get.Responses["200"].Content["application/json"].Schema
+	if len(s.AllOf) != 2 {
+		t.Fatalf("expected allOf with 2 entries, got %+v", s)
+	}
+	if s.AllOf[0].Ref != "#/components/schemas/BaseUser" {
+		t.Errorf("expected ref to BaseUser, got %q", s.AllOf[0].Ref)
+	}
+	if s.AllOf[1].Properties["permissions"] == nil || s.AllOf[1].Properties["BaseUser"] != nil {
+		t.Errorf("expected only own properties in second allOf entry, got %v", s.AllOf[1].Properties)
+	}
+	if base := openapi.Components.Schemas["BaseUser"]; base == nil || base.Properties["name"] == nil {
+		t.Errorf("expected BaseUser component schema, got %+v", base)
+	}
+}
+
+func TestBuildSpec_ResponseContentPerMediaType(t *testing.T) {
+	type UserV1 struct {
+		Name string `json:"name"`
+	}
+	type UserV2 struct {
+		FirstName string `json:"first_name"`
+		LastName  string `json:"last_name"`
+	}
+
+	docs := New(Config{Info: Info{Title: "Test", Version: "1.0.0"}})
+	docs.Add(Endpoint{
+		Method: "GET",
+		Path:   "/users/{id}",
+		Responses: map[int]Response{
+			200: {
+				Description: "User",
+				Schema:      UserV1{},
+				Content:     map[string]interface{}{"application/vnd.myapi.v2+json": UserV2{}},
+			},
+		},
+	})
+
+	content := docs.BuildSpec().Paths["/users/{id}"].Get.Responses["200"].Content
+	if content["application/json"].Schema.Properties["name"] == nil {
+		t.Errorf("expected v1 schema under application/json, got %+v", content["application/json"].Schema)
+	}
+	if content["application/vnd.myapi.v2+json"].Schema.Properties["first_name"] == nil {
+		t.Errorf("expected v2 schema under vendor media type, got %+v", content["application/vnd.myapi.v2+json"].Schema)
+	}
+}
+
+func TestBuildSpec_RateLimit(t *testing.T) {
+	docs := New(Config{Info: Info{Title: "Test", Version: "1.0.0"}})
+	docs.Add(Endpoint{
+		Method:    "POST",
+		Path:      "/login",
+		RateLimit: &RateLimit{Requests: 5, Window: "1m"},
+		Responses: map[int]Response{200: {}, 429: {}},
+	})
+
+	op := docs.BuildSpec().Paths["/login"].Post
+	data, _ := json.Marshal(op)
+	if !strings.Contains(string(data), `"x-ratelimit":{"requests":5,"window":"1m"}`) {
+		t.Errorf("expected x-ratelimit extension, got %s", data)
+	}
+	for code, resp := range op.Responses {
+		if resp.Headers["X-RateLimit-Limit"] == nil || resp.Headers["X-RateLimit-Reset"] == nil {
+			t.Errorf("expected rate limit headers on %s response", code)
+		}
+	}
+}
+
+func TestBuildSpec_OperationIDCollisions(t *testing.T) {
+	endpoints := []Endpoint{
+		{Method: "GET", Path: "/user-list"},
+		{Method: "GET", Path: "/user_list"},
+		{Method: "GET", Path: "/users/{id}", OperationID: "getUserList2"},
+		{Method: "GET", Path: "/user.list"},
+		{Method: "GET", Path: "/teams/{id}"},
+		{Method: "GET", Path: "/teams/:id"},
+	}
+
+	docs := New(Config{Info: Info{Title: "Test", Version: "1.0.0"}})
+	docs.AddAll(endpoints...)
+	openapi := docs.BuildSpec()
+	for path, want := range map[string]string{
+		"/user-list":  "getUserList",
+		"/user_list":  "getUserList3",
+		"/users/{id}": "getUserList2",
+		"/user.list":  "getUserList4",
+	} {
+		if got := openapi.Paths[path].Get.OperationID; got != want {
+			t.Errorf("%s: expected operationId %q, got %q", path, want, got)
+		}
+	}
+	if errs := docs.Validate(); len(errs) != 0 {
+		t.Errorf("expected no errors in auto-suffix mode, got %v", errs)
+	}
+
+	strict := New(Config{Info: Info{Title: "Test", Version: "1.0.0"}, OperationIDCollisions: OperationIDError})
+	strict.AddAll(endpoints...)
+	errs := strict.Validate()
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 collision errors, got %v", errs)
+	}
+	if !strings.Contains(errs[0].Error(), "GET /user-list, GET /user_list, GET /user.list") ||
+		!strings.Contains(errs[1].Error(), "GET /teams/{id}, GET /teams/:id") {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+}
+
+func TestBuildSpec_ExternalExamples(t *testing.T) {
+	docs := New(Config{Info: Info{Title: "Test", Version: "1.0.0"}})
+	docs.Add(Endpoint{
+		Method: "POST",
+		Path:   "/reports",
+		RequestBody: &RequestBody{
+			Schema:   map[string]interface{}{},
+			Examples: map[string]interface{}{"small": map[string]interface{}{"rows": 1}},
+		},
+		Responses: map[int]Response{
+			200: {
+				Description: "Report",
+				Schema:      map[string]interface{}{},
+				Examples:    map[string]interface{}{"full": ExternalExample("https://example.com/report.json")},
+			},
+		},
+	})
+
+	op := docs.BuildSpec().Paths["/reports"].Post
+	if ex := op.RequestBody.Content[ContentTypeJSON].Examples["small"]; ex == nil || ex.Value == nil {
+		t.Errorf("expected inline request example, got %+v", ex)
+	}
+
+	ex := op.Responses["200"].Content[ContentTypeJSON].Examples["full"]
+	if ex == nil || ex.ExternalValue != "https://example.com/report.json" || ex.Value != nil {
+		t.Fatalf("expected external response example, got %+v", ex)
+	}
+	data, err := json.Marshal(ex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"externalValue":"https://example.com/report.json"`) {
+		t.Errorf("expected externalValue in %s", data)
+	}
+}
+
+func TestAddGroup(t *testing.T) {
+	docs := New(Config{Info: Info{Title: "Test", Version: "1.0.0"}})
+	docs.AddGroup("/api/v1/", []string{"bearerAuth"},
+		Endpoint{Method: "GET", Path: "/users"},
+		Endpoint{Method: "GET", Path: "users/{id}", Security: []string{"apiKey"}},
+		Endpoint{Method: "GET", Path: "/health", Public: true},
+	)
+
+	openapi := docs.BuildSpec()
+	if op := openapi.Paths["/api/v1/users"].Get; len(op.Security) != 1 || op.Security[0]["bearerAuth"] == nil {
+		t.Errorf("expected group security on /api/v1/users, got %v", op.Security)
+	}
+	if op := openapi.Paths["/api/v1/users/{id}"].Get; len(op.Security) != 1 || op.Security[0]["apiKey"] == nil {
+		t.Errorf("expected endpoint security to win, got %v", op.Security)
+	}
+	if op := openapi.Paths["/api/v1/health"].Get; op.Security == nil || len(op.Security) != 0 {
+		t.Errorf("expected public endpoint to stay public, got %v", op.Security)
+	}
+}
+
+func TestBuildSpec_SLA(t *testing.T) {
+	docs := New(Config{Info: Info{Title: "Test", Version: "1.0.0"}})
+	docs.Add(Endpoint{
+		Method: "GET",
+		Path:   "/search",
+		SLA:    &SLA{P50: 120 * time.Millisecond, P99: 2 * time.Second},
+	})
+
+	data, _ := json.Marshal(docs.BuildSpec().Paths["/search"].Get)
+	if !strings.Contains(string(data), `"x-sla":{"p50":"120ms","p99":"2s"}`) {
+		t.Errorf("expected x-sla extension, got %s", data)
+	}
+}
+
+func TestBuildSpec_Idempotent(t *testing.T) {
+	docs := New(Config{Info: Info{Title: "Test", Version: "1.0.0"}})
+	docs.AddAll(
+		Endpoint{Method: "POST", Path: "/payments", Description: "Create a payment", Idempotent: true},
+		Endpoint{Method: "PUT", Path: "/payments/{id}", Parameters: []Parameter{IdempotencyKeyParam()}},
+	)
+
+	openapi := docs.BuildSpec()
+	post := openapi.Paths["/payments"].Post
+	if len(post.Parameters) != 1 {
+		t.Fatalf("expected the Idempotency-Key header, got %+v", post.Parameters)
+	}
+	param := post.Parameters[0]
+	if param.Name != IdempotencyKeyHeader || param.In != "header" || param.Required || param.Schema.Format != "uuid" {
+		t.Errorf("unexpected idempotency parameter: %+v", param)
+	}
+	if !strings.HasPrefix(post.Description, "Create a payment\n\n") || !strings.Contains(post.Description, "original response") {
+		t.Errorf("expected replay behavior in description, got %q", post.Description)
+	}
+
+	put := openapi.Paths["/payments/{id}"].Put
+	if !hasParam(put.Parameters, IdempotencyKeyHeader) || put.Description != "" {
+		t.Errorf("expected explicit header without description note, got %+v", put)
+	}
+}
+
+func TestTryItDefaults(t *testing.T) {
+	type CreateUserRequest struct {
+		ID    string `json:"id" swagger:"readOnly"`
+		Name  string `json:"name" example:"Jane"`
+		Email string `json:"email" example:"jane@example.com"`
+	}
+
+	docs := New(Config{Info: Info{Title: "Test", Version: "1.0.0"}})
+	docs.AddAll(
+		Endpoint{
+			Method:     "GET",
+			Path:       "/users",
+			Parameters: append(PaginationParams(), QueryParam("q", "Search").WithExample("jane")),
+		},
+		Endpoint{Method: "POST", Path: "/users", RequestBody: &RequestBody{Schema: CreateUserRequest{}}},
+		Endpoint{Method: "TRACE", Path: "/echo", Parameters: []Parameter{QueryParam("q", "Search").WithExample("ping")}},
+	)
+
+	defaults := docs.TryItDefaults()
+
+	if trace, ok := defaults["traceEcho"].(map[string]interface{}); !ok || trace["parameters"].(map[string]interface{})["q"] != "ping" {
+		t.Errorf("expected defaults for the TRACE operation, got %v", defaults["traceEcho"])
+	}
+
+	params := defaults["getUsers"].(map[string]interface{})["parameters"].(map[string]interface{})
+	if params["page"] != 1 || params["per_page"] != 20 || params["q"] != "jane" {
+		t.Errorf("unexpected parameter defaults: %v", params)
+	}
+
+	body := defaults["postUsers"].(map[string]interface{})["body"].(map[string]interface{})
+	if body["name"] != "Jane" || body["email"] != "jane@example.com" {
+		t.Errorf("unexpected body defaults: %v", body)
+	}
+	if _, ok := body["id"]; ok {
+		t.Error("expected readOnly id to be left out of the body")
+	}
+}
+
+func TestBuildSpec_ResponseLinks(t *testing.T) {
+	type User struct {
+		ID string `json:"id"`
+	}
+
+	docs := New(Config{Info: Info{Title: "Test", Version: "1.0.0"}})
+	docs.AddAll(
+		Endpoint{Method: "POST", Path: "/users", Responses: map[int]Response{
+			201: {Schema: User{}, Links: map[string]Link{
+				"GetUser": {
+					OperationID: "getUser",
+					Description: "Fetch the created user",
+					Parameters:  map[string]string{"id": "$response.body#/id"},
+				},
+				"RenameUser": {
+					OperationID: "renameUser",
+					RequestBody: map[string]interface{}{"id": "$response.body#/id"},
+				},
+			}},
+		}},
+		Endpoint{Method: "GET", Path: "/users/{id}", OperationID: "getUser"},
+		Endpoint{Method: "PATCH", Path: "/users/{id}", OperationID: "renameUser"},
+	)
+
+	data, err := docs.SpecJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Paths map[string]map[string]struct {
+			Responses map[string]struct {
+				Links map[string]map[string]interface{} `json:"links"`
+			} `json:"responses"`
+		} `json:"paths"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	links := doc.Paths["/users"]["post"].Responses["201"].Links
+	get := links["GetUser"]
+	if get["operationId"] != "getUser" || get["description"] != "Fetch the created user" {
+		t.Errorf("unexpected GetUser link %v", get)
+	}
+	if params, ok := get["parameters"].(map[string]interface{}); !ok || params["id"] != "$response.body#/id" {
+		t.Errorf("expected the id parameter expression, got %v", get["parameters"])
+	}
+	rename := links["RenameUser"]
+	if body, ok := rename["requestBody"].(map[string]interface{}); !ok || body["id"] != "$response.body#/id" || rename["parameters"] != nil {
+		t.Errorf("expected the request body expression without parameters, got %v", rename)
+	}
+}
+
+func TestBuildSpec_EndpointServers(t *testing.T) {
+	docs := New(Config{
+		Info:    Info{Title: "Test", Version: "1.0.0"},
+		Servers: []Server{{URL: "https://api.example.com"}},
+	})
+	docs.AddAll(
+		Endpoint{Method: "POST", Path: "/uploads", Servers: []Server{{URL: "https://upload.example.com", Description: "Upload ingest"}}},
+		Endpoint{Method: "GET", Path: "/users"},
+	)
+
+	openapi := docs.BuildSpec()
+	uploads := openapi.Paths["/uploads"].Post
+	if len(uploads.Servers) != 1 || uploads.Servers[0].URL != "https://upload.example.com" || uploads.Servers[0].Description != "Upload ingest" {
+		t.Errorf("expected the upload server on the operation, got %+v", uploads.Servers)
+	}
+	if users := openapi.Paths["/users"].Get; len(users.Servers) != 0 {
+		t.Errorf("expected other operations to use the global servers, got %+v", users.Servers)
+	}
+
+	defaults := docs.TryItDefaults()
+	if entry, ok := defaults["postUploads"].(map[string]interface{}); !ok || entry["server"] != "https://upload.example.com" {
+		t.Errorf("expected Try-It to target the upload server, got %v", defaults["postUploads"])
+	}
+	if _, ok := defaults["getUsers"]; ok {
+		t.Errorf("expected no Try-It server override for /users, got %v", defaults["getUsers"])
+	}
+}
+
+func TestBuildSpec_Webhooks(t *testing.T) {
+	type OrderPlaced struct {
+		OrderID string `json:"order_id"`
+	}
+
+	docs := New(Config{Info: Info{Title: "Test", Version: "1.0.0"}})
+	docs.AddWebhook("orderPlaced", Webhook{
+		Summary:   "Order placed",
+		Payload:   OrderPlaced{},
+		Responses: map[int]Response{200: {Description: "Acknowledge the delivery"}},
+		Retry:     &RetryPolicy{MaxAttempts: 3, Timeout: 5 * time.Second, RetryOn: []string{"5xx", "timeout"}},
+	})
+
+	openapi := docs.BuildSpec()
+	op := openapi.Webhooks["orderPlaced"].Post
+	if op == nil || op.RequestBody == nil || op.Responses["200"].Description != "Acknowledge the delivery" {
+		t.Fatalf("expected POST webhook with payload and subscriber response, got %+v", op)
+	}
+	if !strings.Contains(op.Description, "time out after 5s and are retried up to 3 times on 5xx, timeout.") {
+		t.Errorf("expected retry note in description, got %q", op.Description)
+	}
+	data, _ := json.Marshal(op)
+	if !strings.Contains(string(data), `"x-webhook-retry":{"maxAttempts":3,"retryOn":["5xx","timeout"],"timeout":"5s"}`) {
+		t.Errorf("expected x-webhook-retry extension, got %s", data)
+	}
+
+	// The retry note doesn't depend on documented subscriber responses
+	docs.AddWebhook("orderShipped", Webhook{Payload: OrderPlaced{}, Retry: &RetryPolicy{MaxAttempts: 5, Backoff: "exponential"}})
+	shipped := docs.BuildSpec().Webhooks["orderShipped"].Post
+	if shipped.Description != "**Receiving this webhook:** Deliveries are retried up to 5 times with exponential backoff." {
+		t.Errorf("expected the retry note without responses, got %q", shipped.Description)
+	}
+}
+
+func TestWithValidationErrors(t *testing.T) {
+	type FieldError struct {
+		Field   string `json:"field"`
+		Message string `json:"message"`
+	}
+	type ValidationErrorResponse struct {
+		Errors []FieldError `json:"errors"`
+	}
+
+	docs := New(Config{Info: Info{Title: "Test", Version: "1.0.0"}}).
+		WithValidationErrors(ValidationErrorResponse{})
+	docs.AddAll(
+		Endpoint{Method: "POST", Path: "/users", RequestBody: &RequestBody{Schema: map[string]interface{}{}}},
+		Endpoint{Method: "PUT", Path: "/users/{id}", RequestBody: &RequestBody{Schema: map[string]interface{}{}},
+			Responses: map[int]Response{422: {Description: "Email already taken"}}},
+		Endpoint{Method: "GET", Path: "/users"},
+	)
+
+	openapi := docs.BuildSpec()
+	resp := openapi.Paths["/users"].Post.Responses["422"]
+	if resp == nil || resp.Content[ContentTypeJSON].Schema.Properties["errors"] == nil {
+		t.Fatalf("expected 422 with the error schema, got %+v", resp)
+	}
+	if desc := openapi.Paths["/users/{id}"].Put.Responses["422"].Description; desc != "Email already taken" {
+		t.Errorf("expected the endpoint's own 422 to win, got %q", desc)
+	}
+	if _, ok := openapi.Paths["/users"].Get.Responses["422"]; ok {
+		t.Error("expected no 422 on an endpoint without a request body")
+	}
+}
+
+func TestBuildSpec_StyledPathParams(t *testing.T) {
+	integer := spec.NewSchema("integer")
+
+	docs := New(Config{Info: Info{Title: "Test", Version: "1.0.0"}})
+	docs.AddAll(
+		Endpoint{Method: "GET", Path: "/tiles/{z}/{x},{y}", Parameters: []Parameter{
+			PathParam("z", "Zoom level").WithSchema(integer),
+			PathParam("x", "Column").WithSchema(integer),
+		}},
+		Endpoint{Method: "GET", Path: "/points/{coords}", Parameters: []Parameter{
+			ArrayPathParam("coords", "Coordinates", "number").WithStyle("matrix", true),
+		}},
+	)
+
+	openapi := docs.BuildSpec()
+	tiles := openapi.Paths["/tiles/{z}/{x},{y}"].Get.Parameters
+	if len(tiles) != 3 || tiles[2].Name != "y" || tiles[1].Schema.Type != "integer" {
+		t.Errorf("expected z, x and an auto-detected y, got %+v", tiles)
+	}
+
+	coords := openapi.Paths["/points/{coords}"].Get.Parameters[0]
+	if coords.Style != "matrix" || !coords.Explode || coords.Schema.Type != "array" || coords.Schema.Items.Type != "number" {
+		t.Errorf("unexpected matrix parameter: %+v", coords)
+	}
+	if errs := docs.Validate(); len(errs) != 0 {
+		t.Errorf("expected no validation errors, got %v", errs)
+	}
+}
+
+func TestBuildSpec_ResponseWrappers(t *testing.T) {
+	type User struct {
+		ID string `json:"id"`
+	}
+
+	docs := New(Config{
+		Info: Info{Title: "Test", Version: "1.0.0"},
+		ResponseWrapper: Envelope("data", map[string]*spec.Schema{
+			"meta": {Type: "object"},
+		}),
+		ErrorWrapper: Envelope("error", nil),
+	})
+	docs.AddAll(
+		Endpoint{Method: "GET", Path: "/users/{id}", Responses: map[int]Response{
+			200: {Schema: User{}},
+			404: {Schema: User{}},
+		}},
+		Endpoint{Method: "GET", Path: "/health", RawResponses: true, Responses: map[int]Response{
+			200: {Schema: User{}},
+		}},
+	)
+	openapi := docs.BuildSpec()
+
+	responses := openapi.Paths["/users/{id}"].Get.Responses
+	ok := responses["200"].Content[ContentTypeJSON].Schema
+	if ok.Properties["data"] == nil || ok.Properties["meta"] == nil {
+		t.Errorf("expected data/meta envelope, got %+v", ok)
+	}
+	if notFound := responses["404"].Content[ContentTypeJSON].Schema; notFound.Properties["error"] == nil {
+		t.Errorf("expected error envelope, got %+v", notFound)
+	}
+	if raw := openapi.Paths["/health"].Get.Responses["200"].Content[ContentTypeJSON].Schema; raw.Properties["data"] != nil {
+		t.Error("expected RawResponses to skip the envelope")
+	}
+}
+
+func TestBuildSpec_Timeout(t *testing.T) {
+	docs := New(Config{Info: Info{Title: "Test", Version: "1.0.0"}})
+	docs.AddAll(
+		Endpoint{Method: "POST", Path: "/reports/{id}/generate", Timeout: 2 * time.Minute},
+		Endpoint{Method: "GET", Path: "/users"},
+	)
+
+	op := docs.BuildSpec().Paths["/reports/{id}/generate"].Post
+	if op.Extensions["x-timeout-ms"] != int64(120000) {
+		t.Errorf("expected x-timeout-ms, got %v", op.Extensions)
+	}
+	if !strings.Contains(op.Description, "2m0s") {
+		t.Errorf("expected a timeout note, got %q", op.Description)
+	}
+
+	target, _ := url.Parse("https://api.example.com/api/v1/reports/42/generate")
+	if timeout := docs.endpointTimeout("POST", target); timeout != 2*time.Minute {
+		t.Errorf("expected the endpoint timeout behind a base path, got %v", timeout)
+	}
+	target, _ = url.Parse("https://api.example.com/users")
+	if timeout := docs.endpointTimeout("GET", target); timeout != 0 {
+		t.Errorf("expected no timeout, got %v", timeout)
+	}
+}
+
+func TestBuildSpec_PreferVariants(t *testing.T) {
+	type User struct {
+		ID string `json:"id"`
+	}
+
+	docs := New(Config{Info: Info{Title: "Test", Version: "1.0.0"}})
+	docs.Add(Endpoint{Method: "PUT", Path: "/users/{id}", Responses: map[int]Response{
+		200: {Schema: User{}, Prefer: map[string]interface{}{
+			"return=minimal":        nil,
+			"return=representation": User{},
+		}},
+	}})
+
+	op := docs.BuildSpec().Paths["/users/{id}"].Put
+	variants, ok := op.Extensions["x-prefer-variants"].(map[string]interface{})
+	if !ok || len(variants["200"].(map[string]interface{})) != 2 {
+		t.Fatalf("expected two variants for 200, got %v", op.Extensions)
+	}
+
+	var prefer *spec.Parameter
+	for _, p := range op.Parameters {
+		if p.Name == PreferHeader {
+			prefer = p
+		}
+	}
+	if prefer == nil || prefer.In != "header" || len(prefer.Schema.Enum) != 2 {
+		t.Errorf("expected a Prefer header with both preferences, got %+v", prefer)
+	}
+	if op.Responses["200"].Headers["Preference-Applied"] == nil {
+		t.Error("expected the Preference-Applied response header")
+	}
+}
+
+func TestBuildSpec_ParameterExamples(t *testing.T) {
+	status := QueryParam("status", "Filter by status").
+		WithNamedExample("active", Example{Summary: "Only active", Value: "active"}).
+		WithNamedExample("banned", Example{Summary: "Only banned", Value: "banned"})
+
+	docs := New(Config{Info: Info{Title: "Test", Version: "1.0.0"}})
+	docs.AddAll(
+		Endpoint{Method: "GET", Path: "/users", Parameters: []Parameter{status}},
+		Endpoint{Method: "GET", Path: "/orders", Parameters: []Parameter{status.WithExample("active")}},
+	)
+	openapi := docs.BuildSpec()
+
+	p := openapi.Paths["/users"].Get.Parameters[0]
+	if len(p.Examples) != 2 || p.Examples["banned"].Summary != "Only banned" || p.Example != nil {
+		t.Errorf("expected two named examples, got %+v", p)
+	}
+	if p := openapi.Paths["/orders"].Get.Parameters[0]; p.Example != "active" || p.Examples != nil {
+		t.Errorf("expected the single example to win, got %+v", p)
+	}
+
+	errs := docs.Validate()
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "both Example and Examples") {
+		t.Errorf("expected a mutual exclusivity error, got %v", errs)
+	}
+}
+
+func TestToPostmanCollection(t *testing.T) {
+	type CreateUser struct {
+		Name string `json:"name" example:"Jane"`
+	}
+
+	docs := New(Config{
+		Info:     Info{Title: "Users API", Version: "1.0.0"},
+		Servers:  []Server{{URL: "https://api.example.com/"}},
+		Security: []string{SecurityBearerAuth},
+	})
+	docs.AddAll(
+		Endpoint{Method: "POST", Path: "/users", Summary: "Create user", Tags: []string{"Users"},
+			RequestBody: &RequestBody{Schema: CreateUser{}}},
+		Endpoint{Method: "GET", Path: "/users/:id", Tags: []string{"Users"},
+			Parameters: []Parameter{QueryParam("expand", "Related resources")}},
+		Endpoint{Method: "GET", Path: "/health", Public: true},
+	)
+
+	data, err := docs.ToPostmanCollection()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var collection postmanCollection
+	if err := json.Unmarshal(data, &collection); err != nil {
+		t.Fatal(err)
+	}
+
+	if collection.Info.Schema != postmanSchema || collection.Variable[0].Value != "https://api.example.com" {
+		t.Errorf("unexpected collection info %+v / %+v", collection.Info, collection.Variable)
+	}
+	if collection.Auth == nil || collection.Auth.Type != "bearer" {
+		t.Errorf("expected collection bearer auth, got %+v", collection.Auth)
+	}
+	if len(collection.Item) != 2 || collection.Item[0].Name != "Users" || len(collection.Item[0].Item) != 2 {
+		t.Fatalf("expected a Users folder and a top-level request, got %+v", collection.Item)
+	}
+
+	create := collection.Item[0].Item[0].Request
+	if create.URL.Raw != "{{baseUrl}}/users" || create.Body == nil || !strings.Contains(create.Body.Raw, `"name": "Jane"`) {
+		t.Errorf("unexpected create request %+v", create)
+	}
+	get := collection.Item[0].Item[1].Request
+	if strings.Join(get.URL.Path, "/") != "users/:id" || len(get.URL.Query) != 1 || !get.URL.Query[0].Disabled {
+		t.Errorf("unexpected get request url %+v", get.URL)
+	}
+	if health := collection.Item[1].Request; health.Auth == nil || health.Auth.Type != "noauth" {
+		t.Errorf("expected the public endpoint to use noauth, got %+v", health.Auth)
+	}
+}
+
+func TestToInsomnia(t *testing.T) {
+	type CreateUser struct {
+		Name string `json:"name" example:"Jane"`
+	}
+
+	docs := New(Config{
+		Info:     Info{Title: "Users API", Version: "1.0.0"},
+		Servers:  []Server{{URL: "https://api.example.com"}},
+		Security: []string{SecurityBearerAuth},
+	})
+	docs.AddAll(
+		Endpoint{Method: "POST", Path: "/users", Summary: "Create user", Tags: []string{"Users"},
+			RequestBody: &RequestBody{Schema: CreateUser{}}},
+		Endpoint{Method: "GET", Path: "/users/:id", Tags: []string{"Users"}},
+		Endpoint{Method: "GET", Path: "/health", Public: true},
+	)
+
+	data, err := docs.ToInsomnia()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var export insomniaExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		t.Fatal(err)
+	}
+
+	if export.Type != "export" || export.ExportFormat != 4 {
+		t.Errorf("unexpected export header %+v", export)
+	}
+	byType := map[string][]insomniaResource{}
+	for _, r := range export.Resources {
+		byType[r.Type] = append(byType[r.Type], r)
+	}
+	if env := byType["environment"]; len(env) != 1 || env[0].Data["baseUrl"] != "https://api.example.com" {
+		t.Errorf("unexpected environment %+v", env)
+	}
+	groups := byType["request_group"]
+	if len(groups) != 1 || groups[0].Name != "Users" {
+		t.Fatalf("expected a Users request group, got %+v", groups)
+	}
+
+	requests := byType["request"]
+	if len(requests) != 3 {
+		t.Fatalf("expected 3 requests, got %d", len(requests))
+	}
+	create := requests[0]
+	if *create.ParentID != groups[0].ID || create.URL != "{{ _.baseUrl }}/users" ||
+		create.Body == nil || !strings.Contains(create.Body.Text, `"name": "Jane"`) {
+		t.Errorf("unexpected create request %+v", create)
+	}
+	if create.Authentication["type"] != "bearer" {
+		t.Errorf("expected the global bearer auth, got %v", create.Authentication)
+	}
+	if get := requests[1]; get.URL != "{{ _.baseUrl }}/users/:id" || len(get.PathParameters) != 1 {
+		t.Errorf("unexpected get request %+v", get)
+	}
+	if health := requests[2]; health.Authentication["type"] != "none" {
+		t.Errorf("expected the public endpoint to use no auth, got %v", health.Authentication)
+	}
+}
+
+func TestBuildSpec_HeadAndOptions(t *testing.T) {
+	docs := New(Config{Info: Info{Title: "API", Version: "1.0.0"}})
+	docs.AddAll(
+		Endpoint{Method: "GET", Path: "/users/:id", Summary: "Get user"},
+		Endpoint{Method: "HEAD", Path: "/users/:id", Summary: "Check user exists"},
+		OPTIONS("/users/:id").Summary("CORS preflight").Build(),
+	)
+
+	item := docs.BuildSpec().Paths["/users/{id}"]
+	if item == nil || item.Get == nil {
+		t.Fatalf("expected the GET operation, got %+v", item)
+	}
+	if item.Head == nil || item.Head.Summary != "Check user exists" {
+		t.Errorf("expected the HEAD operation, got %+v", item.Head)
+	}
+	if item.Options == nil || item.Options.Summary != "CORS preflight" {
+		t.Errorf("expected the OPTIONS operation, got %+v", item.Options)
+	}
+}
+
+func TestBuildSpec_AllowEmptyValueAndReserved(t *testing.T) {
+	docs := New(Config{Info: Info{Title: "Test", Version: "1.0.0"}})
+	docs.Add(Endpoint{
+		Method: "GET",
+		Path:   "/reports",
+		Parameters: []Parameter{
+			QueryParam("verbose", "Include debug details").WithSchema(spec.NewSchema("boolean")).MarkAllowEmptyValue(),
+			QueryParam("redirect", "URL to return to").MarkAllowReserved(),
+		},
+	})
+
+	data, _ := docs.SpecJSON()
+	var doc struct {
+		Paths map[string]map[string]struct {
+			Parameters []map[string]interface{} `json:"parameters"`
+		} `json:"paths"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	params := doc.Paths["/reports"]["get"].Parameters
+	if len(params) != 2 {
+		t.Fatalf("expected 2 parameters, got %v", params)
+	}
+	if params[0]["allowEmptyValue"] != true || params[0]["allowReserved"] != nil {
+		t.Errorf("expected the verbose flag to allow an empty value, got %v", params[0])
+	}
+	if params[1]["allowReserved"] != true || params[1]["allowEmptyValue"] != nil {
+		t.Errorf("expected redirect to allow reserved characters, got %v", params[1])
+	}
+
+	docs.Add(Endpoint{
+		Method:     "GET",
+		Path:       "/exports",
+		Parameters: []Parameter{HeaderParam("X-Trace", "Trace flag").MarkAllowEmptyValue()},
+	})
+	if errs := docs.Validate(); len(errs) != 1 || !strings.Contains(errs[0].Error(), "X-Trace") {
+		t.Errorf("expected an error for a header allowing an empty value, got %v", errs)
+	}
+}
+
+func TestValidate_UnknownMethod(t *testing.T) {
+	docs := New(Config{Info: Info{Title: "API", Version: "1.0.0"}})
+	docs.AddAll(
+		Endpoint{Method: "GETT", Path: "/users"},
+		Endpoint{Method: "TRACE", Path: "/debug"},
+	)
+
+	openapi := docs.BuildSpec()
+	if _, ok := openapi.Paths["/users"]; ok {
+		t.Error("expected the endpoint with an unknown method to be skipped")
+	}
+	if item := openapi.Paths["/debug"]; item == nil || item.Trace == nil {
+		t.Errorf("expected the TRACE operation, got %+v", item)
+	}
+
+	errs := docs.Validate()
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), `unknown HTTP method "GETT"`) {
+		t.Errorf("expected an unknown method error, got %v", errs)
+	}
+}
+
+type statsBase struct {
+	ID string `json:"id"`
+}
+
+func TestStats(t *testing.T) {
+	type User struct {
+		statsBase
+		Name string `json:"name"`
+	}
+	type RenameUser struct {
+		Name string `json:"name"`
+	}
+
+	docs := New(Config{
+		Info:     Info{Title: "API", Version: "1.0.0"},
+		Security: []string{SecurityBearerAuth},
+	})
+	docs.AddAll(
+		Endpoint{Method: "GET", Path: "/users", Tags: []string{"Users"},
+			Parameters: []Parameter{QueryParam("page", "Page"), QueryParam("limit", "Limit")},
+			Responses:  map[int]Response{200: {Description: "OK", Schema: []User{}}}},
+		Endpoint{Method: "GET", Path: "/users/:id", Tags: []string{"Users"}, Deprecated: true},
+		Endpoint{Method: "DELETE", Path: "/users/:id", Tags: []string{"Users", "Admin"}},
+		Endpoint{Method: "GET", Path: "/health", Public: true},
+	)
+	docs.AddWebhook("userCreated", Webhook{Payload: User{}})
+	docs.AddWebhook("userRenamed", Webhook{Payload: RenameUser{}})
+
+	stats := docs.Stats()
+	if stats.Operations != 4 || stats.ByMethod["GET"] != 3 || stats.ByMethod["DELETE"] != 1 {
+		t.Errorf("unexpected operation counts %+v", stats)
+	}
+	if stats.ByTag["Users"] != 3 || stats.ByTag["Admin"] != 1 {
+		t.Errorf("unexpected tag counts %v", stats.ByTag)
+	}
+	// User, its embedded statsBase and RenameUser, each counted once
+	if stats.Schemas != 3 || stats.Deprecated != 1 {
+		t.Errorf("expected 3 schemas and 1 deprecated operation, got %+v", stats)
+	}
+	if stats.Secured != 3 || stats.Public != 1 {
+		t.Errorf("expected 3 secured and 1 public operations, got %+v", stats)
+	}
+	if stats.AvgParameters != 1 {
+		t.Errorf("expected 1 parameter per operation on average, got %v", stats.AvgParameters)
+	}
+}
+
+func TestBuildSpec_CacheControl(t *testing.T) {
+	docs := New(Config{Info: Info{Title: "API", Version: "1.0.0"}})
+	docs.AddAll(
+		Endpoint{Method: "GET", Path: "/products", CacheControl: Cacheable(300),
+			Responses: map[int]Response{200: {Description: "OK"}, 404: {Description: "Not found"}}},
+		Endpoint{Method: "POST", Path: "/products"},
+	)
+
+	item := docs.BuildSpec().Paths["/products"]
+	get := item.Get
+	if get.Extensions["x-cache-control"] != "public, max-age=300" {
+		t.Errorf("expected x-cache-control, got %v", get.Extensions)
+	}
+	if h := get.Responses["200"].Headers["Cache-Control"]; h == nil || h.Example != "public, max-age=300" {
+		t.Errorf("expected a Cache-Control header on 200, got %+v", h)
+	}
+	if _, ok := get.Responses["404"].Headers["Cache-Control"]; ok {
+		t.Error("expected no Cache-Control header on 404")
+	}
+	if _, ok := item.Post.Extensions["x-cache-control"]; ok {
+		t.Error("expected no x-cache-control on a non-cacheable endpoint")
+	}
+}
+
+func TestAsyncAPI_Webhooks(t *testing.T) {
+	type UserCreated struct {
+		ID string `json:"id"`
+	}
+
+	docs := New(Config{Info: Info{Title: "API", Version: "1.0.0"}})
+	docs.AddWebhook("user.created", Webhook{Summary: "User created", Payload: UserCreated{}})
+
+	doc := docs.AsyncAPI()
+	channel := doc.Channels["user.created"]
+	if channel == nil || channel.Subscribe == nil || channel.Subscribe.Summary != "User created" {
+		t.Fatalf("expected a subscribe channel for the webhook, got %+v", channel)
+	}
+	if msg := doc.Components.Messages["user.created"]; msg == nil || msg.Payload.Properties["id"] == nil {
+		t.Errorf("expected the webhook payload schema, got %+v", msg)
+	}
+}
+
+func TestBuildSpec_InfoFields(t *testing.T) {
+	docs := New(Config{Info: Info{
+		Title:          "API",
+		Version:        "1.0.0",
+		Summary:        "Manage users",
+		TermsOfService: "https://example.com/terms",
+		License:        &License{Name: "Apache 2.0", Identifier: "Apache-2.0"},
+	}})
+
+	data, err := json.Marshal(docs.BuildSpec())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"termsOfService":"https://example.com/terms"`) ||
+		!strings.Contains(string(data), `"identifier":"Apache-2.0"`) ||
+		!strings.Contains(string(data), `"summary":"Manage users"`) {
+		t.Errorf("expected termsOfService, the summary and the license identifier in info, got %s", data)
+	}
+
+	docs30 := New(Config{OpenAPIVersion: "3.0.3", Info: docs.config.Info})
+	data, err = json.Marshal(docs30.BuildSpec())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), `"identifier"`) || strings.Contains(string(data), `"summary":"Manage users"`) {
+		t.Errorf("expected no license identifier or info summary in a 3.0 document, got %s", data)
+	}
+}
+
+func TestBuildSpec_XMLFieldNames(t *testing.T) {
+	type User struct {
+		UserName string `json:"userName" xml:"user_name"`
+	}
+
+	docs := New(Config{Info: Info{Title: "API", Version: "1.0.0"}})
+	docs.Add(Endpoint{Method: "GET", Path: "/users/:id", Responses: map[int]Response{
+		200: {Description: "OK", Schema: User{}, Content: map[string]interface{}{ContentTypeXML: User{}}},
+	}})
+
+	content := docs.BuildSpec().Paths["/users/{id}"].Get.Responses["200"].Content
+	if content[ContentTypeJSON].Schema.Properties["userName"] == nil {
+		t.Errorf("expected the json field name, got %+v", content[ContentTypeJSON].Schema.Properties)
+	}
+	if content[ContentTypeXML].Schema.Properties["user_name"] == nil {
+		t.Errorf("expected the xml field name, got %+v", content[ContentTypeXML].Schema.Properties)
+	}
+}
+
+type xmlBase struct {
+	CreatedAt string `json:"createdAt" xml:"created_at"`
+}
+
+type xmlUser struct {
+	xmlBase
+	Name string `json:"name" xml:"user_name"`
+}
+
+func TestBuildSpec_DefaultResponseNullable30(t *testing.T) {
+	docs := New(Config{Info: Info{Title: "API", Version: "1.0.0"}, OpenAPIVersion: "3.0.3"})
+	docs.Add(Endpoint{Method: "GET", Path: "/users", Responses: map[int]Response{200: {Description: "OK"}}})
+	docs.Use(func(openapi *spec.OpenAPI) error {
+		problem := &spec.Schema{Type: "object", Properties: map[string]*spec.Schema{
+			"detail": {Type: "string", Nullable: true},
+		}}
+		openapi.Paths["/users"].Get.AddResponse("default", spec.NewResponse("Unexpected error").WithContent(ContentTypeJSON, problem))
+		return nil
+	})
+
+	data, err := docs.SpecJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Paths map[string]map[string]struct {
+			Responses map[string]struct {
+				Content map[string]struct {
+					Schema struct {
+						Properties map[string]map[string]interface{} `json:"properties"`
+					} `json:"schema"`
+				} `json:"content"`
+			} `json:"responses"`
+		} `json:"paths"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	detail := doc.Paths["/users"]["get"].Responses["default"].Content["application/json"].Schema.Properties["detail"]
+	if detail["type"] != "string" || detail["nullable"] != true {
+		t.Errorf("expected a 3.0 nullable field in the default response, got %v", detail)
+	}
+}
+
+func TestBuildSpec_XMLEmbeddedBase(t *testing.T) {
+	docs := New(Config{Info: Info{Title: "API", Version: "1.0.0"}})
+	docs.Add(Endpoint{Method: "GET", Path: "/users/:id", Responses: map[int]Response{
+		200: {Description: "OK", Schema: xmlUser{}, Content: map[string]interface{}{ContentTypeXML: xmlUser{}}},
+	}})
+
+	openapi := docs.BuildSpec()
+	content := openapi.Paths["/users/{id}"].Get.Responses["200"].Content
+	if ref := content[ContentTypeJSON].Schema.AllOf[0].Ref; ref != "#/components/schemas/xmlBase" {
+		t.Errorf("expected the JSON body to reference xmlBase, got %q", ref)
+	}
+	if ref := content[ContentTypeXML].Schema.AllOf[0].Ref; ref != "#/components/schemas/xmlBase_xml" {
+		t.Errorf("expected the XML body to reference xmlBase_xml, got %q", ref)
+	}
+
+	schemas := openapi.Components.Schemas
+	if schemas["xmlBase"] == nil || schemas["xmlBase"].Properties["createdAt"] == nil {
+		t.Errorf("expected json names in xmlBase, got %+v", schemas["xmlBase"])
+	}
+	if schemas["xmlBase_xml"] == nil || schemas["xmlBase_xml"].Properties["created_at"] == nil {
+		t.Errorf("expected xml names in xmlBase_xml, got %+v", schemas["xmlBase_xml"])
+	}
+}
+
+// warnRecorder records the messages logged as warnings
+type warnRecorder struct {
+	nopLogger
+	warnings []string
+}
+
+func (w *warnRecorder) Warn(msg string, args ...any) {
+	w.warnings = append(w.warnings, fmt.Sprint(append([]any{msg}, args...)...))
+}
+
+func TestBuildSpec_WarnsMissingResponseSchema(t *testing.T) {
+	logger := &warnRecorder{}
+	docs := New(Config{Info: Info{Title: "API", Version: "1.0.0"}, Logger: logger})
+	docs.AddAll(
+		Endpoint{Method: "GET", Path: "/users", Responses: map[int]Response{
+			200: {Description: "Users"},
+			404: {Description: "Not found"},
+		}},
+		Endpoint{Method: "DELETE", Path: "/users/:id", Responses: map[int]Response{
+			204: NoContentResponse("Deleted"),
+		}},
+		Endpoint{Method: "GET", Path: "/export", Responses: map[int]Response{
+			200: FileDownloadResponse("Export", "text/csv"),
+		}},
+	)
+	docs.BuildSpec()
+
+	if len(logger.warnings) != 1 || !strings.Contains(logger.warnings[0], "/users") || !strings.Contains(logger.warnings[0], "200") {
+		t.Errorf("expected one warning for GET /users 200, got %v", logger.warnings)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	yamlPath := filepath.Join(dir, "docs.yaml")
+	if err := os.WriteFile(yamlPath, []byte(`
+info:
+  title: Users API
+  version: 2.0.0
+servers:
+  - url: https://api.example.com
+    description: Production
+ui:
+  theme: dark
+docsAuth:
+  enabled: true
+  username: admin
+  password: secret
+  sessionTtl: 30m
+`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig(yamlPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Info.Title != "Users API" || cfg.Info.Version != "2.0.0" || len(cfg.Servers) != 1 ||
+		cfg.UI.Theme != "dark" || cfg.DocsAuth == nil || cfg.DocsAuth.Username != "admin" {
+		t.Errorf("unexpected config %+v", cfg)
+	}
+	if cfg.DocsAuth.SessionTTL != 30*time.Minute {
+		t.Errorf("expected a 30m session TTL, got %v", cfg.DocsAuth.SessionTTL)
+	}
+
+	badPath := filepath.Join(dir, "bad.yaml")
+	if err := os.WriteFile(badPath, []byte("docsAuth:\n  sessionTtl: soon\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadConfig(badPath); err == nil || !strings.Contains(err.Error(), "soon") {
+		t.Errorf("expected an invalid session TTL to be rejected, got %v", err)
+	}
+
+	jsonPath := filepath.Join(dir, "docs.json")
+	if err := os.WriteFile(jsonPath, []byte(`{"info": {"title": "API", "version": "1"}, "security": ["bearerAuth"]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadConfig(jsonPath); err == nil || !strings.Contains(err.Error(), "security") {
+		t.Errorf("expected settings outside the file config to be rejected, got %v", err)
+	}
+}
+
+func TestBuildSpec_CompressionNegotiation(t *testing.T) {
+	docs := New(Config{Info: Info{Title: "API", Version: "1.0.0"}})
+	docs.Add(Endpoint{
+		Method:     "GET",
+		Path:       "/reports",
+		Parameters: []Parameter{AcceptEncodingParam("gzip", "br")},
+		Responses: map[int]Response{200: {
+			Description: "Report",
+			Headers:     map[string]ResponseHeader{"Content-Encoding": ContentEncodingHeader("gzip", "br")},
+		}},
+	})
+
+	op := docs.BuildSpec().Paths["/reports"].Get
+	if len(op.Parameters) != 1 || op.Parameters[0].In != "header" || op.Parameters[0].Name != "Accept-Encoding" {
+		t.Fatalf("expected the Accept-Encoding header parameter, got %+v", op.Parameters)
+	}
+	if enum := op.Parameters[0].Schema.Enum; len(enum) != 2 || enum[0] != "gzip" || enum[1] != "br" {
+		t.Errorf("expected the encodings enum, got %v", enum)
+	}
+	h := op.Responses["200"].Headers["Content-Encoding"]
+	if h == nil || len(h.Schema.Enum) != 2 {
+		t.Errorf("expected the Content-Encoding response header, got %+v", h)
+	}
+}
+
+func TestValidate_StrictStructure(t *testing.T) {
+	docs := New(Config{Info: Info{Title: "API", Version: "1.0.0"}, StrictValidation: true})
+	docs.AddAll(
+		Endpoint{Method: "GET", Path: "/users", Responses: map[int]Response{200: {Description: "OK"}}},
+		Endpoint{Method: "POST", Path: "/users"},
+	)
+
+	errs := docs.Validate()
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "#/paths/~1users/post/responses: must not be empty") {
+		t.Errorf("expected the empty responses of POST /users to be reported, got %v", errs)
+	}
+
+	docs.config.StrictValidation = false
+	if errs := docs.Validate(); len(errs) != 0 {
+		t.Errorf("expected no structure errors without StrictValidation, got %v", errs)
+	}
+
+	docs30 := New(Config{Info: Info{Title: "API", Version: "1.0.0"}, OpenAPIVersion: "3.0.3", StrictValidation: true})
+	docs30.Add(Endpoint{Method: "GET", Path: "/users", Responses: map[int]Response{200: {Description: "OK"}}})
+	if errs := docs30.Validate(); len(errs) != 0 {
+		t.Errorf("expected the 3.1 structure check to be skipped for 3.0, got %v", errs)
+	}
+	if errs := docs30.ValidateAgainstMetaSchema(); len(errs) != 1 || !strings.Contains(errs[0].Error(), "requires OpenAPI 3.1") {
+		t.Errorf("expected ValidateAgainstMetaSchema to report the 3.0 version, got %v", errs)
+	}
+}
@@ -0,0 +1,199 @@
+package openswag
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// OperationIDHeader is the response header set by OperationIDMiddleware
+const OperationIDHeader = "X-Operation-Id"
+
+// operationID returns the endpoint's explicit OperationID, or derives one from
+// its method and path (GET /users/{id}/posts -> getUsersByIdPosts)
+func operationID(ep Endpoint) string {
+	if ep.OperationID != "" || ep.Path == "" {
+		return ep.OperationID
+	}
+
+	var sb strings.Builder
+	sb.WriteString(strings.ToLower(ep.Method))
+	for _, part := range strings.Split(ep.Path, "/") {
+		if part == "" {
+			continue
+		}
+		if name, ok := pathParamName(part); ok {
+			sb.WriteString("By")
+			part = name
+		}
+		sb.WriteString(camelWord(part))
+	}
+	return sb.String()
+}
+
+// pathParamName returns the parameter name of a :name or {name} path segment
+func pathParamName(segment string) (string, bool) {
+	if strings.HasPrefix(segment, ":") {
+		return strings.TrimPrefix(segment, ":"), true
+	}
+	if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+		return strings.Trim(segment, "{}"), true
+	}
+	return "", false
+}
+
+// camelWord upper-cases the first letter of every alphanumeric run in s
+func camelWord(s string) string {
+	var sb strings.Builder
+	upper := true
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// OperationIDCollisionMode controls what happens when two endpoints derive the same operationId
+type OperationIDCollisionMode string
+
+const (
+	// OperationIDAutoSuffix appends 2, 3, ... to later duplicates (default)
+	OperationIDAutoSuffix OperationIDCollisionMode = "suffix"
+	// OperationIDError keeps duplicates as they are and reports them from
+	// Validate, forcing explicit Endpoint.OperationID values (useful for SDK generation)
+	OperationIDError OperationIDCollisionMode = "error"
+)
+
+// operationIDs resolves the operationId of every registered endpoint, in
+// registration order. Explicit IDs are kept verbatim; in auto-suffix mode
+// derived IDs that collide get a numeric suffix. Callers must hold d.mu.
+func (d *Docs) operationIDs() []string {
+	ids := make([]string, len(d.endpoints))
+	used := make(map[string]bool)
+	for i, ep := range d.endpoints {
+		if ep.OperationID != "" {
+			ids[i] = ep.OperationID
+			used[ep.OperationID] = true
+		}
+	}
+
+	suffix := d.config.OperationIDCollisions != OperationIDError
+	for i, ep := range d.endpoints {
+		if ep.OperationID != "" {
+			continue
+		}
+		id := operationID(ep)
+		if suffix && id != "" && used[id] {
+			for n := 2; ; n++ {
+				if candidate := id + strconv.Itoa(n); !used[candidate] {
+					id = candidate
+					break
+				}
+			}
+		}
+		ids[i] = id
+		used[id] = true
+	}
+	return ids
+}
+
+// operationIDCollisions returns one error per operationId shared by several
+// endpoints, listing every colliding method and path
+func (d *Docs) operationIDCollisions() []error {
+	ids := d.operationIDs()
+	byID := make(map[string][]string)
+	var order []string
+	for i, id := range ids {
+		if id == "" {
+			continue
+		}
+		if _, ok := byID[id]; !ok {
+			order = append(order, id)
+		}
+		byID[id] = append(byID[id], d.endpoints[i].Method+" "+d.endpoints[i].Path)
+	}
+
+	var errs []error
+	for _, id := range order {
+		if routes := byID[id]; len(routes) > 1 {
+			errs = append(errs, fmt.Errorf("operationId %q is used by %s", id, strings.Join(routes, ", ")))
+		}
+	}
+	return errs
+}
+
+// OperationIDMiddleware sets the X-Operation-Id response header to the
+// operationId of the documented endpoint matching each request
+func (d *Docs) OperationIDMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if id := d.matchOperationID(r.Method, r.URL.Path); id != "" {
+				w.Header().Set(OperationIDHeader, id)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// matchOperationID finds the endpoint matching method and path and returns
+// its operationId
+func (d *Docs) matchOperationID(method, path string) string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if i, ok := d.matchEndpoint(method, path); ok {
+		return d.operationIDs()[i]
+	}
+	return ""
+}
+
+// matchEndpoint returns the index of the endpoint matching method and path.
+// When several templates match, the one with the most literal segments wins.
+// Callers must hold d.mu.
+func (d *Docs) matchEndpoint(method, path string) (int, bool) {
+	requestParts := strings.Split(strings.Trim(path, "/"), "/")
+	best, bestScore := -1, -1
+
+	for i, ep := range d.endpoints {
+		if !strings.EqualFold(ep.Method, method) {
+			continue
+		}
+		if score, ok := matchPath(ep.Path, requestParts); ok && score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	return best, best >= 0
+}
+
+// matchPath reports whether a path template matches the request segments and
+// returns the number of literal segments that matched
+func matchPath(template string, requestParts []string) (int, bool) {
+	templateParts := strings.Split(strings.Trim(template, "/"), "/")
+	if len(templateParts) != len(requestParts) {
+		return 0, false
+	}
+
+	score := 0
+	for i, part := range templateParts {
+		if _, ok := pathParamName(part); ok {
+			if requestParts[i] == "" {
+				return 0, false
+			}
+			continue
+		}
+		if part != requestParts[i] {
+			return 0, false
+		}
+		score++
+	}
+	return score, true
+}
@@ -0,0 +1,48 @@
+package openswag
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOperationID(t *testing.T) {
+	tests := []struct {
+		ep       Endpoint
+		expected string
+	}{
+		{Endpoint{Method: "GET", Path: "/users"}, "getUsers"},
+		{Endpoint{Method: "GET", Path: "/users/{id}/posts"}, "getUsersByIdPosts"},
+		{Endpoint{Method: "DELETE", Path: "/api-keys/:key_id"}, "deleteApiKeysByKeyId"},
+		{Endpoint{Method: "POST", Path: "/users", OperationID: "createUser"}, "createUser"},
+	}
+
+	for _, tt := range tests {
+		if got := operationID(tt.ep); got != tt.expected {
+			t.Errorf("operationID(%s %s): expected %q, got %q", tt.ep.Method, tt.ep.Path, tt.expected, got)
+		}
+	}
+}
+
+func TestOperationIDMiddleware(t *testing.T) {
+	docs := New(Config{Info: Info{Title: "Test", Version: "1.0.0"}})
+	docs.AddAll(
+		Endpoint{Method: "GET", Path: "/users/{id}", OperationID: "getUser"},
+		Endpoint{Method: "GET", Path: "/users/me", OperationID: "getCurrentUser"},
+	)
+
+	handler := docs.OperationIDMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	tests := map[string]string{
+		"/users/42": "getUser",
+		"/users/me": "getCurrentUser",
+		"/orders/1": "",
+	}
+	for path, expected := range tests {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if got := rec.Header().Get(OperationIDHeader); got != expected {
+			t.Errorf("%s: expected %q, got %q", path, expected, got)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package openswag
+
+import "github.com/andrianprasetya/open-swag-go/pkg/spec"
+
+// PathParam creates a required path parameter
+func PathParam(name, description string) Parameter {
+	return Parameter{Name: name, In: "path", Description: description, Required: true}
+}
+
+// ArrayPathParam creates a required path parameter holding a list of
+// itemType values, serialized comma-separated unless WithStyle says otherwise
+func ArrayPathParam(name, description, itemType string) Parameter {
+	return PathParam(name, description).WithSchema(&spec.Schema{
+		Type:  "array",
+		Items: spec.NewSchema(itemType),
+	})
+}
+
+// QueryParam creates an optional query parameter
+func QueryParam(name, description string) Parameter {
+	return Parameter{Name: name, In: "query", Description: description}
+}
+
+// RequiredQueryParam creates a required query parameter
+func RequiredQueryParam(name, description string) Parameter {
+	return Parameter{Name: name, In: "query", Description: description, Required: true}
+}
+
+// HeaderParam creates an optional header parameter
+func HeaderParam(name, description string) Parameter {
+	return Parameter{Name: name, In: "header", Description: description}
+}
+
+// CookieParam creates an optional cookie parameter
+func CookieParam(name, description string) Parameter {
+	return Parameter{Name: name, In: "cookie", Description: description}
+}
+
+// WithSchema sets the parameter schema
+func (p Parameter) WithSchema(s *spec.Schema) Parameter {
+	p.Schema = s
+	return p
+}
+
+// WithExample sets the parameter example
+func (p Parameter) WithExample(example interface{}) Parameter {
+	p.Example = example
+	return p
+}
+
+// WithNamedExample adds a named example, a plain value or an Example with a
+// summary such as Example{Summary: "Only active", Value: "active"}
+func (p Parameter) WithNamedExample(name string, example interface{}) Parameter {
+	examples := make(map[string]interface{}, len(p.Examples)+1)
+	for key, value := range p.Examples {
+		examples[key] = value
+	}
+	examples[name] = example
+	p.Examples = examples
+	return p
+}
+
+// WithStyle sets how the parameter value is serialized: "simple" (default),
+// "label" (.1.2) or "matrix" (;id=1,2) for path parameters, "form",
+// "spaceDelimited" or "pipeDelimited" for query parameters
+func (p Parameter) WithStyle(style string, explode bool) Parameter {
+	p.Style = style
+	p.Explode = explode
+	return p
+}
+
+// MarkAllowEmptyValue documents that the query parameter may be sent
+// without a value, as in a ?verbose flag
+func (p Parameter) MarkAllowEmptyValue() Parameter {
+	p.AllowEmptyValue = true
+	return p
+}
+
+// MarkAllowReserved documents that the query parameter value may contain
+// reserved characters that are not percent-encoded, e.g. a path or URL
+func (p Parameter) MarkAllowReserved() Parameter {
+	p.AllowReserved = true
+	return p
+}
+
+// MarkDeprecated marks the parameter as deprecated
+func (p Parameter) MarkDeprecated() Parameter {
+	p.Deprecated = true
+	return p
+}
+
+// PaginationParams returns the page and per_page query parameters shared by list endpoints
+func PaginationParams() []Parameter {
+	minimum, maxPerPage := 1.0, 100.0
+	return []Parameter{
+		QueryParam("page", "Page number").WithSchema(&spec.Schema{
+			Type:    "integer",
+			Default: 1,
+			Minimum: &minimum,
+		}),
+		QueryParam("per_page", "Items per page").WithSchema(&spec.Schema{
+			Type:    "integer",
+			Default: 20,
+			Minimum: &minimum,
+			Maximum: &maxPerPage,
+		}),
+	}
+}
+
+// SortParams returns a sort query parameter limited to the given fields and
+// an order parameter accepting asc or desc
+func SortParams(fields ...string) []Parameter {
+	sortSchema := &spec.Schema{Type: "string"}
+	for _, field := range fields {
+		sortSchema.Enum = append(sortSchema.Enum, field)
+	}
+	if len(fields) > 0 {
+		sortSchema.Default = fields[0]
+	}
+
+	return []Parameter{
+		QueryParam("sort", "Field to sort by").WithSchema(sortSchema),
+		QueryParam("order", "Sort direction").WithSchema(&spec.Schema{
+			Type:    "string",
+			Enum:    []any{"asc", "desc"},
+			Default: "asc",
+		}),
+	}
+}
@@ -0,0 +1,45 @@
+package openswag
+
+import (
+	"fmt"
+
+	"github.com/andrianprasetya/open-swag-go/pkg/spec"
+)
+
+// schemaPatch is a pending PatchSchema call
+type schemaPatch struct {
+	pointer string
+	apply   func(*spec.Schema)
+}
+
+// PatchSchema registers a tweak for one generated schema, applied after
+// BuildSpec constructs the spec. pointer is a JSON pointer into the spec,
+// e.g. "/paths/~1users/post/requestBody" or "/components/schemas/User".
+// Patches run in registration order; unresolved pointers are logged and
+// reported by Validate.
+func (d *Docs) PatchSchema(pointer string, patch func(*spec.Schema)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.patches = append(d.patches, schemaPatch{pointer: pointer, apply: patch})
+	d.invalidate()
+}
+
+// applyPatches runs the registered schema patches against a freshly built
+// spec, recording unresolved pointers when report is set
+func (d *Docs) applyPatches(openapi *spec.OpenAPI, report bool) {
+	if report {
+		d.patchErrors = nil
+	}
+	for _, p := range d.patches {
+		s, err := openapi.SchemaAt(p.pointer)
+		if err != nil {
+			if !report {
+				continue
+			}
+			d.patchErrors = append(d.patchErrors, fmt.Errorf("PatchSchema: %w", err))
+			d.config.Logger.Warn("openswag: unresolved schema patch", "pointer", p.pointer, "error", err)
+			continue
+		}
+		p.apply(s)
+	}
+}
@@ -0,0 +1,211 @@
+// Package asyncapi builds AsyncAPI 2.x documents for event-driven APIs,
+// converting Go payload types with the same schema converter as the
+// OpenAPI spec, so REST and event surfaces share their types.
+package asyncapi
+
+import (
+	"encoding/json"
+
+	"github.com/andrianprasetya/open-swag-go/pkg/schema"
+)
+
+// Version is the AsyncAPI version of generated documents
+const Version = "2.6.0"
+
+// Document is an AsyncAPI 2.x document
+type Document struct {
+	AsyncAPI   string                  `json:"asyncapi"`
+	Info       Info                    `json:"info"`
+	Servers    map[string]Server       `json:"servers,omitempty"`
+	Channels   map[string]*ChannelItem `json:"channels"`
+	Components *Components             `json:"components,omitempty"`
+
+	opts schema.Options
+}
+
+// Info describes the application
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// Server is a message broker, e.g. {URL: "kafka:9092", Protocol: "kafka"}
+type Server struct {
+	URL         string `json:"url"`
+	Protocol    string `json:"protocol"`
+	Description string `json:"description,omitempty"`
+}
+
+// ChannelItem holds the operations available on a channel (topic, queue, ...).
+// In AsyncAPI 2.x, Subscribe documents messages the application sends and
+// clients receive; Publish documents messages clients send to the application.
+type ChannelItem struct {
+	Description string     `json:"description,omitempty"`
+	Subscribe   *Operation `json:"subscribe,omitempty"`
+	Publish     *Operation `json:"publish,omitempty"`
+}
+
+// Operation is a publish or subscribe operation on a channel
+type Operation struct {
+	OperationID string `json:"operationId,omitempty"`
+	Summary     string `json:"summary,omitempty"`
+	Description string `json:"description,omitempty"`
+	Tags        []Tag  `json:"tags,omitempty"`
+	// Message references a single message, or lists several under oneOf
+	Message *MessageRef `json:"message"`
+}
+
+// Tag groups operations
+type Tag struct {
+	Name string `json:"name"`
+}
+
+// MessageRef references component messages
+type MessageRef struct {
+	Ref   string       `json:"$ref,omitempty"`
+	OneOf []MessageRef `json:"oneOf,omitempty"`
+}
+
+// Message is a component message with its payload schema
+type Message struct {
+	Name        string         `json:"name"`
+	Title       string         `json:"title,omitempty"`
+	Summary     string         `json:"summary,omitempty"`
+	Description string         `json:"description,omitempty"`
+	ContentType string         `json:"contentType,omitempty"`
+	Payload     *schema.Schema `json:"payload,omitempty"`
+}
+
+// Components holds the reusable messages and payload schemas
+type Components struct {
+	Messages map[string]*Message       `json:"messages,omitempty"`
+	Schemas  map[string]*schema.Schema `json:"schemas,omitempty"`
+}
+
+// Event documents a message exchanged on a channel
+type Event struct {
+	// Name identifies the message under components/messages
+	Name        string
+	Summary     string
+	Description string
+	OperationID string
+	Tags        []string
+	// ContentType defaults to application/json
+	ContentType string
+	// Payload is a value of the message body's Go type
+	Payload interface{}
+}
+
+// New creates an empty document
+func New(info Info) *Document {
+	return &Document{
+		AsyncAPI: Version,
+		Info:     info,
+		Channels: make(map[string]*ChannelItem),
+	}
+}
+
+// WithSchemaOptions sets the options used to convert payload types, e.g.
+// the field naming of the OpenAPI spec describing the same types
+func (d *Document) WithSchemaOptions(opts schema.Options) *Document {
+	d.opts = opts
+	return d
+}
+
+// AddServer adds a broker under the given name
+func (d *Document) AddServer(name string, server Server) *Document {
+	if d.Servers == nil {
+		d.Servers = make(map[string]Server)
+	}
+	d.Servers[name] = server
+	return d
+}
+
+// Subscribe documents an event the application sends on the channel
+func (d *Document) Subscribe(channel string, event Event) *Document {
+	item := d.channel(channel)
+	item.Subscribe = d.addEvent(item.Subscribe, event)
+	return d
+}
+
+// Publish documents an event the application receives on the channel
+func (d *Document) Publish(channel string, event Event) *Document {
+	item := d.channel(channel)
+	item.Publish = d.addEvent(item.Publish, event)
+	return d
+}
+
+// JSON renders the document as indented JSON
+func (d *Document) JSON() ([]byte, error) {
+	return json.MarshalIndent(d, "", "  ")
+}
+
+func (d *Document) channel(name string) *ChannelItem {
+	item := d.Channels[name]
+	if item == nil {
+		item = &ChannelItem{}
+		d.Channels[name] = item
+	}
+	return item
+}
+
+// addEvent registers the event's message and adds it to the operation; a
+// second event on the same operation turns its message into a oneOf
+func (d *Document) addEvent(op *Operation, event Event) *Operation {
+	ref := MessageRef{Ref: "#/components/messages/" + event.Name}
+	d.addMessage(event)
+
+	if op == nil {
+		op = &Operation{
+			OperationID: event.OperationID,
+			Summary:     event.Summary,
+			Description: event.Description,
+			Message:     &ref,
+		}
+		for _, tag := range event.Tags {
+			op.Tags = append(op.Tags, Tag{Name: tag})
+		}
+		return op
+	}
+
+	if op.Message.Ref != "" {
+		op.Message = &MessageRef{OneOf: []MessageRef{*op.Message}}
+	}
+	op.Message.OneOf = append(op.Message.OneOf, ref)
+	return op
+}
+
+// addMessage converts the payload and registers the message along with the
+// component schemas it references
+func (d *Document) addMessage(event Event) {
+	if d.Components == nil {
+		d.Components = &Components{}
+	}
+	if d.Components.Messages == nil {
+		d.Components.Messages = make(map[string]*Message)
+	}
+
+	msg := &Message{
+		Name:        event.Name,
+		Title:       event.Summary,
+		Summary:     event.Summary,
+		Description: event.Description,
+		ContentType: event.ContentType,
+	}
+	if msg.ContentType == "" {
+		msg.ContentType = "application/json"
+	}
+	if event.Payload != nil {
+		// Embedded structs are registered as component schemas
+		opts := d.opts
+		opts.ComposeEmbedded = true
+		msg.Payload = schema.FromTypeWithOptions(event.Payload, opts)
+
+		if d.Components.Schemas == nil {
+			d.Components.Schemas = make(map[string]*schema.Schema)
+		}
+		schema.CollectDefinitions(msg.Payload, d.Components.Schemas)
+	}
+	d.Components.Messages[event.Name] = msg
+}
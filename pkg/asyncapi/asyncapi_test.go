@@ -0,0 +1,64 @@
+package asyncapi
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type Address struct {
+	City string `json:"city"`
+}
+
+type OrderPlaced struct {
+	Address
+	OrderID string  `json:"order_id"`
+	Total   float64 `json:"total"`
+}
+
+type OrderCancelled struct {
+	OrderID string `json:"order_id"`
+}
+
+type PlaceOrder struct {
+	SKU string `json:"sku"`
+}
+
+func TestDocument_Channels(t *testing.T) {
+	doc := New(Info{Title: "Orders", Version: "1.0.0"}).
+		AddServer("production", Server{URL: "kafka:9092", Protocol: "kafka"}).
+		Subscribe("orders", Event{Name: "OrderPlaced", Summary: "An order was placed", Payload: OrderPlaced{}}).
+		Subscribe("orders", Event{Name: "OrderCancelled", Payload: OrderCancelled{}}).
+		Publish("commands.place-order", Event{Name: "PlaceOrder", Payload: PlaceOrder{}})
+
+	data, err := doc.JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out map[string]interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out["asyncapi"] != Version {
+		t.Errorf("expected asyncapi %s, got %v", Version, out["asyncapi"])
+	}
+
+	orders := doc.Channels["orders"]
+	if orders == nil || orders.Subscribe == nil || len(orders.Subscribe.Message.OneOf) != 2 {
+		t.Fatalf("expected two subscribe messages on orders, got %+v", orders)
+	}
+	if orders.Subscribe.Message.OneOf[1].Ref != "#/components/messages/OrderCancelled" {
+		t.Errorf("unexpected message ref %+v", orders.Subscribe.Message.OneOf[1])
+	}
+	command := doc.Channels["commands.place-order"]
+	if command == nil || command.Publish == nil || command.Publish.Message.Ref != "#/components/messages/PlaceOrder" {
+		t.Errorf("expected a publish operation, got %+v", command)
+	}
+
+	placed := doc.Components.Messages["OrderPlaced"]
+	if placed == nil || placed.ContentType != "application/json" || len(placed.Payload.AllOf) != 2 {
+		t.Errorf("unexpected OrderPlaced message %+v", placed)
+	}
+	if doc.Components.Schemas["Address"] == nil {
+		t.Errorf("expected the embedded Address schema in components, got %v", doc.Components.Schemas)
+	}
+}
@@ -17,6 +17,21 @@ func NewFaker() *Faker {
 	}
 }
 
+// NewFakerWithSeed creates a faker that produces the same sequence for the same seed
+func NewFakerWithSeed(seed int64) *Faker {
+	return &Faker{
+		rng: rand.New(rand.NewSource(seed)),
+	}
+}
+
+// FromEnum picks a random member of an enum, or nil if there are none
+func (f *Faker) FromEnum(values []any) any {
+	if len(values) == 0 {
+		return nil
+	}
+	return values[f.rng.Intn(len(values))]
+}
+
 // String generates a random string
 func (f *Faker) String() string {
 	words := []string{"lorem", "ipsum", "dolor", "sit", "amet", "consectetur", "adipiscing", "elit"}
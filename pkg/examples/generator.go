@@ -4,17 +4,46 @@ import (
 	"reflect"
 	"strings"
 	"time"
+
+	"github.com/andrianprasetya/open-swag-go/pkg/schema"
 )
 
 // Config for example generation
 type Config struct {
-	UseFaker     bool
 	TypeExamples map[string]interface{}
+	// UseFaker fills enum fields with a random allowed value instead of the first one
+	UseFaker bool
+	// Seed makes faked values reproducible (0 seeds from the current time)
+	Seed int64
+	// OnlyRequired emits only required fields, producing a minimal valid example
+	OnlyRequired bool
+	// UseTemplates uses a registered template as the example of a struct whose
+	// type name matches the template name (e.g. UserResponse ~ "user")
+	UseTemplates bool
+	// Templates is the registry consulted when UseTemplates is set (defaults to NewTemplateRegistry)
+	Templates *TemplateRegistry
+	// Context skips readOnly fields for requests and writeOnly fields for responses
+	Context Context
+	// FieldNaming names fields without a json tag, matching the schema's property names
+	FieldNaming schema.FieldNaming
 }
 
+// Context tells the generator which direction an example travels in
+type Context int
+
+const (
+	// ContextAny includes every field
+	ContextAny Context = iota
+	// ContextRequest omits readOnly (server-controlled) fields
+	ContextRequest
+	// ContextResponse omits writeOnly fields such as passwords
+	ContextResponse
+)
+
 // Generator generates example values from Go types
 type Generator struct {
 	config Config
+	faker  *Faker
 }
 
 // New creates a new example generator
@@ -22,7 +51,19 @@ func New(config Config) *Generator {
 	if config.TypeExamples == nil {
 		config.TypeExamples = DefaultTypeExamples()
 	}
-	return &Generator{config: config}
+	if config.UseTemplates && config.Templates == nil {
+		config.Templates = NewTemplateRegistry()
+	}
+
+	g := &Generator{config: config}
+	if config.UseFaker {
+		if config.Seed != 0 {
+			g.faker = NewFakerWithSeed(config.Seed)
+		} else {
+			g.faker = NewFaker()
+		}
+	}
+	return g
 }
 
 // DefaultTypeExamples returns default examples for common formats
@@ -62,6 +103,13 @@ func (g *Generator) generateFromType(t reflect.Type) interface{} {
 		return g.generateFromType(t.Elem())
 	}
 
+	// Types that describe themselves supply their own example
+	if schemer, ok := reflect.New(t).Interface().(schema.Schemer); ok {
+		if s := schemer.OpenAPISchema(); s != nil && s.Example != nil {
+			return s.Example
+		}
+	}
+
 	switch t.Kind() {
 	case reflect.String:
 		return "string"
@@ -83,6 +131,11 @@ func (g *Generator) generateFromType(t reflect.Type) interface{} {
 		if t == reflect.TypeOf(time.Time{}) {
 			return "2024-01-15T10:30:00Z"
 		}
+		if g.config.UseTemplates {
+			if tmpl, ok := g.config.Templates.FindByTypeName(t.Name()); ok {
+				return tmpl.Value
+			}
+		}
 		return g.generateFromStruct(t)
 	default:
 		return nil
@@ -95,6 +148,16 @@ func (g *Generator) generateFromStruct(t reflect.Type) map[string]interface{} {
 	for i := 0; i < t.NumField(); i++ {
 		field := t.Field(i)
 
+		// Promote the fields of embedded structs, as encoding/json does
+		if embedded, ok := embeddedStruct(field); ok {
+			for name, value := range g.generateFromStruct(embedded) {
+				if _, exists := result[name]; !exists {
+					result[name] = value
+				}
+			}
+			continue
+		}
+
 		// Skip unexported fields
 		if !field.IsExported() {
 			continue
@@ -106,12 +169,35 @@ func (g *Generator) generateFromStruct(t reflect.Type) map[string]interface{} {
 			continue
 		}
 
+		// Skip optional fields in minimal mode
+		if g.config.OnlyRequired && !schema.IsRequired(field) {
+			continue
+		}
+
+		// Skip fields that don't travel in this direction
+		if g.config.Context == ContextRequest && schema.IsReadOnly(field) {
+			continue
+		}
+		if g.config.Context == ContextResponse && schema.IsWriteOnly(field) {
+			continue
+		}
+
 		// Check for explicit example tag first
 		if example := field.Tag.Get("example"); example != "" {
 			result[name] = example
 			continue
 		}
 
+		// Enumerated fields only accept one of their allowed values
+		if values := schema.FieldEnum(field); len(values) > 0 {
+			if g.faker != nil {
+				result[name] = g.faker.FromEnum(values)
+			} else {
+				result[name] = values[0]
+			}
+			continue
+		}
+
 		// Check format for type examples
 		if format := field.Tag.Get("format"); format != "" {
 			if example, ok := g.config.TypeExamples[format]; ok {
@@ -143,16 +229,26 @@ func (g *Generator) generateFromStruct(t reflect.Type) map[string]interface{} {
 	return result
 }
 
-func (g *Generator) getJSONFieldName(field reflect.StructField) string {
-	tag := field.Tag.Get("json")
-	if tag == "" {
-		return field.Name
+// embeddedStruct returns the struct type of an embedded field without a json name
+func embeddedStruct(field reflect.StructField) (reflect.Type, bool) {
+	if !field.Anonymous || strings.Split(field.Tag.Get("json"), ",")[0] != "" {
+		return nil, false
+	}
+	t := field.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
 	}
-	parts := strings.Split(tag, ",")
-	if parts[0] == "" {
-		return field.Name
+	if t.Kind() != reflect.Struct || t == reflect.TypeOf(time.Time{}) {
+		return nil, false
 	}
-	return parts[0]
+	return t, true
+}
+
+func (g *Generator) getJSONFieldName(field reflect.StructField) string {
+	if name := strings.Split(field.Tag.Get("json"), ",")[0]; name != "" {
+		return name
+	}
+	return g.config.FieldNaming.Apply(field.Name)
 }
 
 func (g *Generator) extractFormat(swagger string) string {
@@ -216,6 +312,13 @@ func (g *Generator) guessFromFieldName(name string, t reflect.Type) interface{}
 	return nil
 }
 
+// GenerateFor creates an example for a request or response body
+func (g *Generator) GenerateFor(t interface{}, ctx Context) interface{} {
+	scoped := *g
+	scoped.config.Context = ctx
+	return scoped.Generate(t)
+}
+
 // GenerateJSON generates example and returns as map suitable for JSON
 func (g *Generator) GenerateJSON(t interface{}) map[string]interface{} {
 	result := g.Generate(t)
@@ -224,3 +327,16 @@ func (g *Generator) GenerateJSON(t interface{}) map[string]interface{} {
 	}
 	return nil
 }
+
+// Presets generates both a "minimal" example with only required fields and a
+// "complete" example with every field, so a UI can offer either as a starting point
+func (g *Generator) Presets(t interface{}) map[string]interface{} {
+	minimal, complete := *g, *g
+	minimal.config.OnlyRequired = true
+	complete.config.OnlyRequired = false
+
+	return map[string]interface{}{
+		"minimal":  minimal.Generate(t),
+		"complete": complete.Generate(t),
+	}
+}
@@ -1,6 +1,9 @@
 package examples
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -78,3 +81,127 @@ func TestGeneratorSlice(t *testing.T) {
 		t.Errorf("expected 1 item, got %d", len(items))
 	}
 }
+
+func TestGeneratorOnlyRequired(t *testing.T) {
+	type CreateUser struct {
+		Name     string `json:"name" validate:"required"`
+		Email    string `json:"email" binding:"required"`
+		Nickname string `json:"nickname"`
+	}
+
+	gen := New(Config{OnlyRequired: true})
+	result := gen.GenerateJSON(CreateUser{})
+
+	if _, ok := result["nickname"]; ok {
+		t.Error("expected optional field 'nickname' to be omitted")
+	}
+	if _, ok := result["name"]; !ok {
+		t.Error("expected required field 'name'")
+	}
+	if _, ok := result["email"]; !ok {
+		t.Error("expected required field 'email'")
+	}
+
+	presets := New(Config{}).Presets(CreateUser{})
+	if len(presets["minimal"].(map[string]interface{})) != 2 {
+		t.Errorf("expected 2 fields in minimal preset, got %v", presets["minimal"])
+	}
+	if len(presets["complete"].(map[string]interface{})) != 3 {
+		t.Errorf("expected 3 fields in complete preset, got %v", presets["complete"])
+	}
+}
+
+func TestGeneratorUseTemplates(t *testing.T) {
+	type UserResponse struct {
+		Name string `json:"name"`
+	}
+	type Wrapper struct {
+		User  UserResponse `json:"user"`
+		Owner UserResponse `json:"owner" example:"explicit"`
+	}
+
+	gen := New(Config{UseTemplates: true})
+	result := gen.GenerateJSON(Wrapper{})
+
+	user, ok := result["user"].(map[string]any)
+	if !ok || user["email"] != "john.doe@example.com" {
+		t.Errorf("expected user template value, got %v", result["user"])
+	}
+	if result["owner"] != "explicit" {
+		t.Errorf("expected explicit example to win, got %v", result["owner"])
+	}
+}
+
+func TestGeneratorContext(t *testing.T) {
+	type User struct {
+		ID       string `json:"id" swagger:"readOnly"`
+		Name     string `json:"name"`
+		Password string `json:"password" swagger:"writeOnly"`
+	}
+
+	gen := New(Config{})
+	request := gen.GenerateFor(User{}, ContextRequest).(map[string]interface{})
+	if _, ok := request["id"]; ok {
+		t.Error("expected readOnly field 'id' to be omitted from request example")
+	}
+	if _, ok := request["password"]; !ok {
+		t.Error("expected writeOnly field 'password' in request example")
+	}
+
+	response := gen.GenerateFor(User{}, ContextResponse).(map[string]interface{})
+	if _, ok := response["password"]; ok {
+		t.Error("expected writeOnly field 'password' to be omitted from response example")
+	}
+	if _, ok := response["id"]; !ok {
+		t.Error("expected readOnly field 'id' in response example")
+	}
+}
+
+func TestGeneratorFakerEnum(t *testing.T) {
+	type Member struct {
+		Role string `json:"role" enum:"admin,editor,viewer"`
+	}
+
+	allowed := map[interface{}]bool{"admin": true, "editor": true, "viewer": true}
+	first := New(Config{UseFaker: true, Seed: 7}).GenerateJSON(Member{})["role"]
+	for i := 0; i < 10; i++ {
+		role := New(Config{UseFaker: true, Seed: 7}).GenerateJSON(Member{})["role"]
+		if !allowed[role] {
+			t.Fatalf("expected an allowed role, got %v", role)
+		}
+		if role != first {
+			t.Errorf("expected seeded faker to be reproducible, got %v and %v", first, role)
+		}
+	}
+
+	if role := New(Config{}).GenerateJSON(Member{})["role"]; role != "admin" {
+		t.Errorf("expected first enum value without faker, got %v", role)
+	}
+}
+
+func TestTemplateRegistryLoadDir(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "invoice.json"), []byte(`{"number": "INV-1", "total": 99.5}`), 0o644)
+	os.WriteFile(filepath.Join(dir, "address.yaml"), []byte("city: Jakarta\nzip: \"10110\"\n"), 0o644)
+	os.WriteFile(filepath.Join(dir, "README.md"), []byte("ignored"), 0o644)
+
+	registry := NewTemplateRegistry()
+	if err := registry.LoadDir(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	invoice, ok := registry.GetValue("invoice")
+	if !ok || invoice.(map[string]any)["number"] != "INV-1" {
+		t.Errorf("expected invoice template, got %v", invoice)
+	}
+	address, ok := registry.GetValue("address")
+	if !ok || address.(map[string]any)["city"] != "Jakarta" {
+		t.Errorf("expected address template, got %v", address)
+	}
+
+	os.WriteFile(filepath.Join(dir, "broken.json"), []byte(`{"number":`), 0o644)
+	err := registry.LoadDir(dir)
+	if err == nil || !strings.Contains(err.Error(), "broken.json") {
+		t.Errorf("expected error naming broken.json, got %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+package examples
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+)
+
+// LoadDir registers every JSON or YAML file in dir as a template, named after
+// the file without its extension (testdata/user.json -> "user"). Other files
+// and subdirectories are ignored; a file that fails to parse aborts the load
+// with an error naming it.
+func (r *TemplateRegistry) LoadDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if ext != ".json" && ext != ".yaml" && ext != ".yml" {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		var value any
+		if ext == ".json" {
+			err = json.Unmarshal(data, &value)
+		} else {
+			err = yaml.Unmarshal(data, &value)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to parse example template %s: %w", path, err)
+		}
+
+		name := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		r.Register(name, Template{
+			Name:        name,
+			Description: "Loaded from " + entry.Name(),
+			Value:       value,
+		})
+	}
+	return nil
+}
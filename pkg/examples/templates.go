@@ -1,5 +1,7 @@
 package examples
 
+import "strings"
+
 // Template represents an example template
 type Template struct {
 	Name        string
@@ -41,6 +43,34 @@ func (r *TemplateRegistry) GetValue(name string) (any, bool) {
 	return t.Value, true
 }
 
+// typeNameSuffixes are stripped from Go type names when matching templates
+var typeNameSuffixes = []string{"response", "request", "resource", "model", "dto"}
+
+// FindByTypeName finds a template matching a Go type name, case-insensitively.
+// An exact match wins; otherwise common suffixes such as "Response" are
+// stripped, so UserResponse matches the "user" template.
+func (r *TemplateRegistry) FindByTypeName(typeName string) (Template, bool) {
+	if typeName == "" {
+		return Template{}, false
+	}
+
+	candidates := []string{strings.ToLower(typeName)}
+	for _, suffix := range typeNameSuffixes {
+		if trimmed := strings.TrimSuffix(candidates[0], suffix); trimmed != candidates[0] && trimmed != "" {
+			candidates = append(candidates, trimmed)
+		}
+	}
+
+	for _, candidate := range candidates {
+		for name, t := range r.templates {
+			if strings.ToLower(name) == candidate {
+				return t, true
+			}
+		}
+	}
+	return Template{}, false
+}
+
 // All returns all registered templates
 func (r *TemplateRegistry) All() map[string]Template {
 	return r.templates
@@ -0,0 +1,117 @@
+package schema
+
+import (
+	"reflect"
+	"sync"
+)
+
+// typeCacheKey identifies a conversion: the same type converts differently
+// under different options
+type typeCacheKey struct {
+	t    reflect.Type
+	opts Options
+}
+
+// typeCache memoizes converted types, so DTOs shared by many endpoints are
+// reflected over once. Entries are stored and returned as deep copies since
+// callers (and field tags) modify the schemas they get. Registering an enum
+// clears the cache; generation keeps a conversion that raced with the
+// registration from storing its stale result.
+var typeCache = struct {
+	mu         sync.RWMutex
+	entries    map[typeCacheKey]*Schema
+	generation uint64
+}{entries: make(map[typeCacheKey]*Schema)}
+
+func cacheKey(t reflect.Type, opts Options) typeCacheKey {
+	// The nesting depth only changes the result when it is capped
+	if opts.MaxDepth <= 0 {
+		opts.depth = 0
+	}
+	return typeCacheKey{t: t, opts: opts}
+}
+
+// cachedSchema returns a copy of the cached conversion and the current
+// generation, to be passed to storeSchema on a miss
+func cachedSchema(key typeCacheKey) (*Schema, uint64, bool) {
+	typeCache.mu.RLock()
+	defer typeCache.mu.RUnlock()
+	s, ok := typeCache.entries[key]
+	if !ok {
+		return nil, typeCache.generation, false
+	}
+	return s.clone(), typeCache.generation, true
+}
+
+// storeSchema caches a copy of s unless the cache was reset since generation
+func storeSchema(key typeCacheKey, s *Schema, generation uint64) {
+	typeCache.mu.Lock()
+	defer typeCache.mu.Unlock()
+	if typeCache.generation == generation {
+		typeCache.entries[key] = s.clone()
+	}
+}
+
+// resetTypeCache drops every cached conversion
+func resetTypeCache() {
+	typeCache.mu.Lock()
+	defer typeCache.mu.Unlock()
+	typeCache.entries = make(map[typeCacheKey]*Schema)
+	typeCache.generation++
+}
+
+// clone deep-copies the schema tree. Extension values and enum, example and
+// default values are user data that is never modified, so they are shared.
+func (s *Schema) clone() *Schema {
+	if s == nil {
+		return nil
+	}
+	c := *s
+
+	if s.Properties != nil {
+		c.Properties = make(map[string]*Schema, len(s.Properties))
+		for name, prop := range s.Properties {
+			c.Properties[name] = prop.clone()
+		}
+	}
+	if s.Definitions != nil {
+		c.Definitions = make(map[string]*Schema, len(s.Definitions))
+		for name, def := range s.Definitions {
+			c.Definitions[name] = def.clone()
+		}
+	}
+	if s.AllOf != nil {
+		c.AllOf = make([]*Schema, len(s.AllOf))
+		for i, member := range s.AllOf {
+			c.AllOf[i] = member.clone()
+		}
+	}
+	c.Items = s.Items.clone()
+
+	c.Required = append([]string(nil), s.Required...)
+	if s.Required != nil && len(s.Required) == 0 {
+		c.Required = []string{}
+	}
+	c.Enum = append([]interface{}(nil), s.Enum...)
+	c.Examples = append([]interface{}(nil), s.Examples...)
+	if s.Extensions != nil {
+		c.Extensions = make(map[string]interface{}, len(s.Extensions))
+		for key, value := range s.Extensions {
+			c.Extensions[key] = value
+		}
+	}
+
+	c.Minimum = clonePtr(s.Minimum)
+	c.Maximum = clonePtr(s.Maximum)
+	c.MinLength = clonePtr(s.MinLength)
+	c.MaxLength = clonePtr(s.MaxLength)
+	return &c
+}
+
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
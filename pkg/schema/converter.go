@@ -1,13 +1,17 @@
 package schema
 
 import (
+	"path"
 	"reflect"
+	"regexp"
+	"sort"
 	"strings"
 	"time"
 )
 
 // Schema represents a JSON Schema
 type Schema struct {
+	Title       string             `json:"title,omitempty"`
 	Type        string             `json:"type,omitempty"`
 	Format      string             `json:"format,omitempty"`
 	Description string             `json:"description,omitempty"`
@@ -16,41 +20,139 @@ type Schema struct {
 	Items       *Schema            `json:"items,omitempty"`
 	Enum        []interface{}      `json:"enum,omitempty"`
 	Example     interface{}        `json:"example,omitempty"`
+	Examples    []interface{}      `json:"examples,omitempty"`
+	Comment     string             `json:"$comment,omitempty"`
 	Default     interface{}        `json:"default,omitempty"`
 	Minimum     *float64           `json:"minimum,omitempty"`
 	Maximum     *float64           `json:"maximum,omitempty"`
 	MinLength   *int               `json:"minLength,omitempty"`
 	MaxLength   *int               `json:"maxLength,omitempty"`
 	Pattern     string             `json:"pattern,omitempty"`
+	Deprecated  bool               `json:"deprecated,omitempty"`
+	ReadOnly    bool               `json:"readOnly,omitempty"`
+	WriteOnly   bool               `json:"writeOnly,omitempty"`
 	Ref         string             `json:"$ref,omitempty"`
+	AllOf       []*Schema          `json:"allOf,omitempty"`
+	// Definitions holds the component schemas referenced via Ref, keyed by
+	// component name, for the caller to register under components/schemas
+	Definitions map[string]*Schema `json:"-"`
+	// Extensions holds "x-" vendor extensions, emitted by the spec package
+	Extensions map[string]interface{} `json:"-"`
+
+	// goType is the Go type a component schema was converted from, telling
+	// apart same-named types of different packages
+	goType reflect.Type
+}
+
+// Schemer is implemented by types that provide their own schema, such as
+// value objects with custom JSON marshalling (a Money type rendered as "12.50 EUR")
+type Schemer interface {
+	OpenAPISchema() *Schema
+}
+
+var schemerType = reflect.TypeOf((*Schemer)(nil)).Elem()
+
+// customSchema returns the schema of a type implementing Schemer on its value
+// or pointer receiver. The result is copied so tags can't modify the original.
+func customSchema(t reflect.Type) (*Schema, bool) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if !reflect.PointerTo(t).Implements(schemerType) {
+		return nil, false
+	}
+	s := reflect.New(t).Interface().(Schemer).OpenAPISchema()
+	if s == nil {
+		return nil, false
+	}
+	custom := *s
+	return &custom, true
+}
+
+// Options customizes the conversion of Go types
+type Options struct {
+	// FieldNaming renames struct fields that have no json or form tag
+	FieldNaming FieldNaming
+	// PreserveFieldOrder records the declaration order of struct fields in
+	// an x-field-order extension, since JSON objects are emitted sorted by key
+	PreserveFieldOrder bool
+	// MaxDepth caps how many levels of nested structs are expanded; deeper
+	// structs are emitted as a plain {"type": "object"}. Zero means unlimited.
+	MaxDepth int
+	// ContentType is the media type the schema describes; it selects the
+	// struct tag naming fields: xml for XML types, form for form bodies and
+	// json otherwise, falling back to json when the preferred tag is absent
+	ContentType string
+	// ComposeEmbedded emits structs embedding named structs as an allOf of
+	// a $ref to each embedded struct's component schema and their own
+	// properties. The component schemas travel in Definitions, so only set
+	// it when the caller registers them (see CollectDefinitions); otherwise
+	// the embedded fields are promoted inline, as encoding/json does.
+	ComposeEmbedded bool
+
+	// depth is the nesting level of the struct being converted
+	depth int
 }
 
 // FromType converts a Go type to JSON Schema
 func FromType(t interface{}) *Schema {
+	return FromTypeWithOptions(t, Options{})
+}
+
+// FromTypeWithOptions converts a Go type to JSON Schema using opts
+func FromTypeWithOptions(t interface{}, opts Options) *Schema {
 	if t == nil {
 		return &Schema{Type: "object"}
 	}
-	return fromReflectType(reflect.TypeOf(t))
+	return fromReflectType(reflect.TypeOf(t), opts)
 }
 
 // FromReflectType converts a reflect.Type to JSON Schema
 func FromReflectType(t reflect.Type) *Schema {
-	return fromReflectType(t)
+	return fromReflectType(t, Options{})
 }
 
-func fromReflectType(t reflect.Type) *Schema {
+func fromReflectType(t reflect.Type, opts Options) *Schema {
 	if t == nil {
 		return &Schema{Type: "object"}
 	}
 
+	// Types that describe themselves are used as-is
+	if custom, ok := customSchema(t); ok {
+		return custom
+	}
+
 	// Handle pointer types
 	if t.Kind() == reflect.Ptr {
-		return fromReflectType(t.Elem())
+		return fromReflectType(t.Elem(), opts)
 	}
 
+	key := cacheKey(t, opts)
+	cached, generation, ok := cachedSchema(key)
+	if ok {
+		return cached
+	}
+
+	schema := schemaForType(t, opts)
+	if values, ok := registeredEnum(t); ok {
+		applyEnum(schema, values)
+	}
+	storeSchema(key, schema, generation)
+	return schema
+}
+
+// timeExamples are the default examples of a time.Time field per format,
+// which a format:"date" or format:"time" tag can select
+var timeExamples = map[string]string{
+	"date-time": "2024-01-01T00:00:00Z",
+	"date":      "2024-01-01",
+	"time":      "00:00:00",
+}
+
+func schemaForType(t reflect.Type, opts Options) *Schema {
 	// Handle time.Time specially
 	if t == reflect.TypeOf(time.Time{}) {
-		return &Schema{Type: "string", Format: "date-time", Example: "2024-01-01T00:00:00Z"}
+		return &Schema{Type: "string", Format: "date-time", Example: timeExamples["date-time"]}
 	}
 
 	switch t.Kind() {
@@ -79,59 +181,89 @@ func fromReflectType(t reflect.Type) *Schema {
 	case reflect.Slice, reflect.Array:
 		return &Schema{
 			Type:  "array",
-			Items: fromReflectType(t.Elem()),
+			Items: fromReflectType(t.Elem(), opts),
 		}
 	case reflect.Struct:
-		return fromStruct(t)
+		return fromStruct(t, opts)
 	case reflect.Map:
 		return &Schema{
 			Type: "object",
 		}
 	case reflect.Interface:
 		return &Schema{Type: "object"}
+	case reflect.Chan, reflect.Func, reflect.Complex64, reflect.Complex128, reflect.UnsafePointer:
+		// Not representable in JSON; document as an unconstrained schema
+		return &Schema{}
 	default:
 		return &Schema{Type: "string", Example: "string"}
 	}
 }
 
-func fromStruct(t reflect.Type) *Schema {
+func fromStruct(t reflect.Type, opts Options) *Schema {
+	if opts.MaxDepth > 0 && opts.depth >= opts.MaxDepth {
+		return &Schema{Type: "object"}
+	}
+	fieldOpts := opts
+	fieldOpts.depth++
+
 	schema := &Schema{
 		Type:       "object",
 		Properties: make(map[string]*Schema),
 		Required:   []string{},
 	}
+	title := structTitle(t)
 
+	var bases []*Schema
+	var order []string
+	promoted := make(map[string]bool)
 	for i := 0; i < t.NumField(); i++ {
 		field := t.Field(i)
 
-		if !field.IsExported() {
+		// Embedded named structs are composed via allOf, or their fields
+		// promoted when the caller doesn't register component schemas
+		if embedded, ok := embeddedStruct(field, opts); ok {
+			if opts.ComposeEmbedded && embedded.Name() != "" {
+				bases = append(bases, embeddedBase(embedded, opts))
+				continue
+			}
+			inner := fromReflectType(embedded, opts)
+			for _, name := range fieldOrder(inner) {
+				if _, exists := schema.Properties[name]; !exists {
+					schema.Properties[name] = inner.Properties[name]
+					promoted[name] = true
+					order = append(order, name)
+				}
+			}
+			for _, name := range inner.Required {
+				if promoted[name] {
+					schema.Required = append(schema.Required, name)
+				}
+			}
 			continue
 		}
 
-		// Get field name from json tag first, then form tag
-		jsonTag := field.Tag.Get("json")
-		if jsonTag == "-" {
+		if !field.IsExported() {
 			continue
 		}
 
-		name := strings.Split(jsonTag, ",")[0]
-		if name == "" {
-			// Fallback to form tag
-			formTag := field.Tag.Get("form")
-			if formTag != "" && formTag != "-" {
-				name = strings.Split(formTag, ",")[0]
-			}
-		}
-		if name == "" {
-			name = field.Name
+		name, ok := fieldName(field, opts)
+		if !ok {
+			continue
 		}
 
 		// Build schema from field type
-		fieldSchema := fromReflectType(field.Type)
+		fieldSchema := fromReflectType(field.Type, fieldOpts)
 
 		// Parse additional tags
 		ParseFieldTags(field, fieldSchema)
 
+		// A field of the struct itself shadows a promoted one, as in encoding/json
+		if promoted[name] {
+			delete(promoted, name)
+			schema.Required = removeString(schema.Required, name)
+		} else {
+			order = append(order, name)
+		}
 		schema.Properties[name] = fieldSchema
 
 		// Check if required
@@ -145,5 +277,207 @@ func fromStruct(t reflect.Type) *Schema {
 		schema.Required = nil
 	}
 
-	return schema
+	if opts.PreserveFieldOrder && len(order) > 0 {
+		if schema.Extensions == nil {
+			schema.Extensions = make(map[string]interface{})
+		}
+		schema.Extensions["x-field-order"] = order
+	}
+
+	if len(bases) == 0 {
+		schema.Title = title
+		return schema
+	}
+
+	composed := &Schema{Title: title, AllOf: bases}
+	if len(schema.Properties) > 0 {
+		composed.AllOf = append(composed.AllOf, schema)
+	}
+	return composed
 }
+
+// fieldName names a struct field from the first of the content type's tags
+// that sets a name, then Options.FieldNaming. Fields are skipped when the
+// content type's own tag is "-", or when json is "-" and the field has no
+// tag of the content type: a json:"-" field stays hidden everywhere unless
+// e.g. a form tag opts it into form bodies. The encoding/xml XMLName field
+// of XML and form schemas is skipped too.
+func fieldName(field reflect.StructField, opts Options) (string, bool) {
+	tags := nameTags(opts.ContentType)
+	if tags[0] != "json" && field.Name == "XMLName" {
+		return "", false
+	}
+	_, ownTag := field.Tag.Lookup(tags[0])
+
+	for _, tag := range tags {
+		value := field.Tag.Get(tag)
+		if value == "-" {
+			if tag == tags[0] || (tag == "json" && !ownTag) {
+				return "", false
+			}
+			continue
+		}
+		if name := strings.Split(value, ",")[0]; name != "" {
+			if tag == "xml" {
+				// a>b>c nests the element; the field itself is the innermost
+				name = name[strings.LastIndex(name, ">")+1:]
+			}
+			return name, true
+		}
+	}
+	return opts.FieldNaming.Apply(field.Name), true
+}
+
+// nameTags returns the struct tags naming fields for a media type, in order
+// of precedence
+func nameTags(contentType string) []string {
+	mediaType := strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	switch {
+	case mediaType == "application/xml" || mediaType == "text/xml" || strings.HasSuffix(mediaType, "+xml"):
+		return []string{"xml", "json"}
+	case mediaType == "application/x-www-form-urlencoded" || mediaType == "multipart/form-data":
+		return []string{"form", "json"}
+	}
+	return []string{"json", "form"}
+}
+
+// Titled is implemented by types that name their schema differently from
+// their Go type name
+type Titled interface {
+	SchemaTitle() string
+}
+
+// structTitle returns the schema title of a struct type: the title tag of a
+// blank marker field (_ struct{} `title:"User"`), the SchemaTitle method, or
+// the type name
+func structTitle(t reflect.Type) string {
+	for i := 0; i < t.NumField(); i++ {
+		if field := t.Field(i); field.Name == "_" {
+			if title := field.Tag.Get("title"); title != "" {
+				return title
+			}
+		}
+	}
+	if titled, ok := reflect.New(t).Interface().(Titled); ok {
+		return titled.SchemaTitle()
+	}
+	return t.Name()
+}
+
+// embeddedStruct returns the struct type of an embedded field whose fields
+// encoding/json would promote, i.e. one without a name in the content
+// type's tag
+func embeddedStruct(field reflect.StructField, opts Options) (reflect.Type, bool) {
+	primary := nameTags(opts.ContentType)[0]
+	if !field.Anonymous || strings.Split(field.Tag.Get(primary), ",")[0] != "" {
+		return nil, false
+	}
+
+	t := field.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t == reflect.TypeOf(time.Time{}) {
+		return nil, false
+	}
+	return t, true
+}
+
+// embeddedBase returns a $ref schema for an embedded named struct. The
+// struct's own schema travels along in Definitions; see CollectDefinitions.
+// Its field names depend on the media type, so XML and form schemas get
+// their own component (Base_xml, Base_form) rather than sharing the JSON one.
+func embeddedBase(t reflect.Type, opts Options) *Schema {
+	name := t.Name()
+	if primary := nameTags(opts.ContentType)[0]; primary != "json" {
+		name += "_" + primary
+	}
+	def := fromReflectType(t, opts)
+	def.goType = t
+	return &Schema{
+		Ref:         componentPrefix + name,
+		Definitions: map[string]*Schema{name: def},
+	}
+}
+
+// fieldOrder returns the property names of s in declaration order when
+// recorded, sorted otherwise
+func fieldOrder(s *Schema) []string {
+	if order, ok := s.Extensions["x-field-order"].([]string); ok {
+		return order
+	}
+	names := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func removeString(list []string, value string) []string {
+	result := list[:0]
+	for _, v := range list {
+		if v != value {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// CollectDefinitions gathers the component schemas referenced anywhere in s
+// into defs, including those referenced by the definitions themselves
+//
+// Components are named after their Go type. When two types of the same name
+// from different packages meet, the later one is qualified with its package
+// (e.g. "billing.Base") and the $ref pointing at it is rewritten.
+func CollectDefinitions(s *Schema, defs map[string]*Schema) {
+	if s == nil {
+		return
+	}
+	for name, def := range s.Definitions {
+		if unique := definitionName(name, def, defs); unique != name {
+			delete(s.Definitions, name)
+			s.Definitions[unique] = def
+			if s.Ref == componentPrefix+name {
+				s.Ref = componentPrefix + unique
+			}
+			name = unique
+		}
+		if _, seen := defs[name]; !seen {
+			defs[name] = def
+			CollectDefinitions(def, defs)
+		}
+	}
+	for _, prop := range s.Properties {
+		CollectDefinitions(prop, defs)
+	}
+	for _, sub := range s.AllOf {
+		CollectDefinitions(sub, defs)
+	}
+	CollectDefinitions(s.Items, defs)
+}
+
+// componentPrefix is the $ref prefix of component schemas
+const componentPrefix = "#/components/schemas/"
+
+// definitionName returns the component name for def: name itself unless
+// defs holds a schema of another Go type under it, then name qualified with
+// the package name, or with the full (sanitized) package path if that's
+// taken too
+func definitionName(name string, def *Schema, defs map[string]*Schema) string {
+	free := func(candidate string) bool {
+		existing, ok := defs[candidate]
+		return !ok || existing.goType == nil || def.goType == nil || existing.goType == def.goType
+	}
+	if free(name) {
+		return name
+	}
+	pkgPath := def.goType.PkgPath()
+	if qualified := path.Base(pkgPath) + "." + name; free(qualified) {
+		return qualified
+	}
+	return invalidComponentChars.ReplaceAllString(pkgPath, "_") + "." + name
+}
+
+// invalidComponentChars matches what OpenAPI doesn't allow in component names
+var invalidComponentChars = regexp.MustCompile(`[^a-zA-Z0-9._-]`)
@@ -2,7 +2,9 @@ package schema
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
+	"time"
 )
 
 type TokenRequest struct {
@@ -147,3 +149,484 @@ func TestFromType_Examples(t *testing.T) {
 		t.Errorf("custom.example should be 'my-custom-value', got %v", schema.Properties["custom"].Example)
 	}
 }
+
+func TestFromType_Deprecated(t *testing.T) {
+	type Account struct {
+		Email    string `json:"email"`
+		Username string `json:"username" swagger:"deprecated"`
+	}
+
+	schema := FromType(Account{})
+
+	if schema.Properties["email"].Deprecated {
+		t.Error("email should not be deprecated")
+	}
+	if !schema.Properties["username"].Deprecated {
+		t.Error("username should be deprecated")
+	}
+}
+
+type orderStatus string
+
+func TestFromType_EnumDescriptions(t *testing.T) {
+	RegisterEnumWithDescriptions(orderStatus(""), []EnumValue{
+		{Value: "pending", Description: "Awaiting approval"},
+		{Value: "shipped", Description: "On its way"},
+	})
+
+	type Order struct {
+		Status orderStatus `json:"status"`
+	}
+
+	schema := FromType(Order{}).Properties["status"]
+
+	if len(schema.Enum) != 2 || schema.Enum[0] != "pending" {
+		t.Errorf("expected enum values, got %v", schema.Enum)
+	}
+	if schema.Example != "pending" {
+		t.Errorf("expected first enum value as example, got %v", schema.Example)
+	}
+	descriptions, ok := schema.Extensions["x-enum-descriptions"].([]string)
+	if !ok || descriptions[1] != "On its way" {
+		t.Errorf("expected x-enum-descriptions, got %v", schema.Extensions)
+	}
+}
+
+type accountState int
+
+func TestFromType_EnumNames(t *testing.T) {
+	RegisterEnumWithNames(accountState(0), map[accountState]string{
+		1: "Active",
+		0: "Unknown",
+		2: "Suspended",
+	})
+
+	type Account struct {
+		State accountState `json:"state"`
+	}
+
+	schema := FromType(Account{}).Properties["state"]
+
+	if schema.Type != "integer" {
+		t.Errorf("expected integer type, got %q", schema.Type)
+	}
+	if len(schema.Enum) != 3 || schema.Enum[0] != int64(0) || schema.Enum[2] != int64(2) {
+		t.Errorf("expected sorted plain integer codes, got %#v", schema.Enum)
+	}
+	names, ok := schema.Extensions["x-enumNames"].([]string)
+	if !ok || len(names) != 3 || names[0] != "Unknown" || names[1] != "Active" || names[2] != "Suspended" {
+		t.Errorf("expected x-enumNames in enum order, got %v", schema.Extensions)
+	}
+	if _, ok := schema.Extensions["x-enum-varnames"]; !ok {
+		t.Errorf("expected x-enum-varnames, got %v", schema.Extensions)
+	}
+	if _, ok := schema.Extensions["x-enum-descriptions"]; ok {
+		t.Error("expected no x-enum-descriptions without descriptions")
+	}
+}
+
+func TestRegisterEnum_ConvertsStringCodes(t *testing.T) {
+	type priority int
+	RegisterEnum(priority(0), "1", 2, "3")
+
+	type Task struct {
+		Priority priority `json:"priority"`
+	}
+
+	schema := FromType(Task{}).Properties["priority"]
+
+	for i, want := range []int64{1, 2, 3} {
+		if schema.Enum[i] != want {
+			t.Errorf("expected enum[%d] = %d, got %#v", i, want, schema.Enum[i])
+		}
+	}
+}
+
+func TestFromType_RequiredIf(t *testing.T) {
+	type ConditionalTokenRequest struct {
+		GrantType    string `json:"grant_type" validate:"required"`
+		RefreshToken string `json:"refresh_token" swagger:"requiredIf=grant_type:refresh_token"`
+	}
+
+	schema := FromType(ConditionalTokenRequest{})
+
+	for _, name := range schema.Required {
+		if name == "refresh_token" {
+			t.Error("expected refresh_token not to be unconditionally required")
+		}
+	}
+
+	prop := schema.Properties["refresh_token"]
+	cond, ok := prop.Extensions["x-required-if"].(map[string]interface{})
+	if !ok || cond["field"] != "grant_type" || cond["value"] != "refresh_token" {
+		t.Errorf("expected x-required-if extension, got %v", prop.Extensions)
+	}
+	if prop.Description != "Required when grant_type is refresh_token." {
+		t.Errorf("unexpected description %q", prop.Description)
+	}
+}
+
+type titledAccount struct {
+	ID string `json:"id"`
+}
+
+func (titledAccount) SchemaTitle() string { return "Account" }
+
+func TestFromType_Title(t *testing.T) {
+	type UserResponse struct {
+		ID string `json:"id"`
+	}
+	type userDTO struct {
+		_  struct{} `title:"User"`
+		ID string   `json:"id"`
+	}
+
+	if title := FromType(UserResponse{}).Title; title != "UserResponse" {
+		t.Errorf("expected title from type name, got %q", title)
+	}
+	if title := FromType(userDTO{}).Title; title != "User" {
+		t.Errorf("expected title from marker field, got %q", title)
+	}
+	if title := FromType(titledAccount{}).Title; title != "Account" {
+		t.Errorf("expected title from SchemaTitle, got %q", title)
+	}
+	if title := FromType(struct{ ID string }{}).Title; title != "" {
+		t.Errorf("expected no title for anonymous struct, got %q", title)
+	}
+}
+
+func TestFromTypeWithOptions_FieldNaming(t *testing.T) {
+	type Profile struct {
+		UserID       string
+		HTTPEndpoint string
+		DisplayName  string `json:"DisplayName"`
+	}
+
+	tests := []struct {
+		naming FieldNaming
+		want   []string
+	}{
+		{FieldNamingAsIs, []string{"UserID", "HTTPEndpoint", "DisplayName"}},
+		{FieldNamingCamel, []string{"userID", "httpEndpoint", "DisplayName"}},
+		{FieldNamingSnake, []string{"user_id", "http_endpoint", "DisplayName"}},
+	}
+
+	for _, tt := range tests {
+		schema := FromTypeWithOptions(Profile{}, Options{FieldNaming: tt.naming})
+		for _, name := range tt.want {
+			if _, ok := schema.Properties[name]; !ok {
+				t.Errorf("%s: expected property %q, got %v", tt.naming, name, schema.Properties)
+			}
+		}
+	}
+}
+
+func TestFromType_TimeFormatOverride(t *testing.T) {
+	type Person struct {
+		BirthDate time.Time  `json:"birth_date" format:"date"`
+		WakeUp    *time.Time `json:"wake_up" swagger:"format=time"`
+		CreatedAt time.Time  `json:"created_at"`
+	}
+
+	schema := FromType(Person{})
+
+	tests := []struct {
+		name, format, example string
+	}{
+		{"birth_date", "date", "2024-01-01"},
+		{"wake_up", "time", "00:00:00"},
+		{"created_at", "date-time", "2024-01-01T00:00:00Z"},
+	}
+	for _, tt := range tests {
+		prop := schema.Properties[tt.name]
+		if prop.Type != "string" || prop.Format != tt.format || prop.Example != tt.example {
+			t.Errorf("%s: expected string/%s with example %q, got %+v", tt.name, tt.format, tt.example, prop)
+		}
+	}
+}
+
+type Money struct {
+	Cents    int64
+	Currency string
+}
+
+func (m *Money) OpenAPISchema() *Schema {
+	return &Schema{Type: "string", Format: "money", Pattern: `^\d+\.\d{2} [A-Z]{3}$`, Example: "12.50 EUR"}
+}
+
+func TestFromType_Schemer(t *testing.T) {
+	type Invoice struct {
+		Total    Money  `json:"total" description:"Invoice total"`
+		Discount *Money `json:"discount"`
+	}
+
+	schema := FromType(Invoice{})
+
+	for _, name := range []string{"total", "discount"} {
+		prop := schema.Properties[name]
+		if prop.Type != "string" || prop.Format != "money" || prop.Properties != nil {
+			t.Errorf("%s: expected custom money schema, got %+v", name, prop)
+		}
+	}
+	if schema.Properties["total"].Description != "Invoice total" {
+		t.Errorf("expected tags to apply on top of the custom schema")
+	}
+	if schema.Properties["discount"].Description != "" {
+		t.Errorf("expected the custom schema not to be shared between fields")
+	}
+}
+
+func TestFromTypeWithOptions_PreserveFieldOrder(t *testing.T) {
+	type Address struct {
+		Street  string `json:"street"`
+		City    string `json:"city"`
+		Country string `json:"country"`
+	}
+
+	order, ok := FromTypeWithOptions(Address{}, Options{PreserveFieldOrder: true}).Extensions["x-field-order"].([]string)
+	if !ok || strings.Join(order, ",") != "street,city,country" {
+		t.Errorf("expected declaration order, got %v", order)
+	}
+	if _, ok := FromType(Address{}).Extensions["x-field-order"]; ok {
+		t.Error("expected no x-field-order by default")
+	}
+}
+
+func TestFromType_ExamplesTag(t *testing.T) {
+	type Order struct {
+		Status   string `json:"status" examples:"pending|shipped" comment:"Mirrors the warehouse states"`
+		Quantity int    `json:"quantity" examples:"1|10" example:"5"`
+	}
+
+	s := FromType(Order{})
+	status := s.Properties["status"]
+	if len(status.Examples) != 2 || status.Examples[1] != "shipped" {
+		t.Errorf("expected two status examples, got %v", status.Examples)
+	}
+	if status.Example != "pending" {
+		t.Errorf("expected the first example as the singular one, got %v", status.Example)
+	}
+	if status.Comment != "Mirrors the warehouse states" {
+		t.Errorf("unexpected comment %q", status.Comment)
+	}
+
+	quantity := s.Properties["quantity"]
+	if quantity.Examples[0] != int64(1) || quantity.Example != "5" {
+		t.Errorf("expected typed examples next to the explicit example, got %v / %v", quantity.Examples, quantity.Example)
+	}
+}
+
+func TestFromTypeWithOptions_MaxDepth(t *testing.T) {
+	type Level6 struct {
+		Value string `json:"value"`
+	}
+	type Level5 struct {
+		Next Level6 `json:"next"`
+	}
+	type Level4 struct {
+		Next Level5 `json:"next"`
+	}
+	type Level3 struct {
+		Next Level4 `json:"next"`
+	}
+	type Level2 struct {
+		Next []Level3 `json:"next"`
+	}
+	type Level1 struct {
+		Next *Level2 `json:"next"`
+	}
+
+	depth := func(s *Schema) int {
+		levels := 0
+		for s != nil && s.Properties != nil {
+			levels++
+			next := s.Properties["next"]
+			if next != nil && next.Items != nil {
+				next = next.Items
+			}
+			s = next
+		}
+		return levels
+	}
+
+	if got := depth(FromType(Level1{})); got != 6 {
+		t.Errorf("expected all 6 levels without a limit, got %d", got)
+	}
+
+	s := FromTypeWithOptions(Level1{}, Options{MaxDepth: 3})
+	if got := depth(s); got != 3 {
+		t.Errorf("expected 3 expanded levels, got %d", got)
+	}
+	cut := s.Properties["next"].Properties["next"].Items.Properties["next"]
+	if cut.Type != "object" || cut.Properties != nil {
+		t.Errorf("expected the 4th level as a plain object, got %+v", cut)
+	}
+}
+
+func TestFromType_ContentTypeFieldNames(t *testing.T) {
+	type Profile struct {
+		XMLName  struct{} `json:"-" xml:"profile"`
+		UserName string   `json:"userName" xml:"user_name" form:"user"`
+		Email    string   `json:"email" xml:"contact>email"`
+		Internal string   `json:"-" xml:"internal"`
+	}
+
+	cases := map[string][]string{
+		"":                                  {"userName", "email"},
+		"application/xml":                   {"user_name", "email", "internal"},
+		"application/vnd.api+xml; q=0.9":    {"user_name", "email", "internal"},
+		"application/x-www-form-urlencoded": {"user", "email"},
+	}
+	for contentType, want := range cases {
+		s := FromTypeWithOptions(Profile{}, Options{ContentType: contentType})
+		if len(s.Properties) != len(want) {
+			t.Errorf("%q: expected properties %v, got %v", contentType, want, s.Properties)
+			continue
+		}
+		for _, name := range want {
+			if s.Properties[name] == nil {
+				t.Errorf("%q: missing property %q in %v", contentType, name, s.Properties)
+			}
+		}
+	}
+}
+
+func TestFromType_FallbackTagDash(t *testing.T) {
+	type Upload struct {
+		Name     string
+		Checksum string `form:"-"`
+		Token    string `json:"-"`
+		Nonce    string `json:"-" form:"nonce"`
+		Retries  int    `json:"-" form:",omitempty"`
+	}
+
+	s := FromType(Upload{})
+	if len(s.Properties) != 2 || s.Properties["Name"] == nil || s.Properties["Checksum"] == nil {
+		t.Errorf("expected json:\"-\" to hide JSON properties, got %v", s.Properties)
+	}
+
+	// json:"-" hides a field from form bodies too, unless it has a form tag
+	form := FromTypeWithOptions(Upload{}, Options{ContentType: "multipart/form-data"})
+	if len(form.Properties) != 3 || form.Properties["Name"] == nil || form.Properties["nonce"] == nil || form.Properties["Retries"] == nil {
+		t.Errorf("expected only Name, nonce and Retries in the form, got %v", form.Properties)
+	}
+}
+
+type auditBase struct {
+	ID        int    `json:"id" validate:"required"`
+	CreatedBy string `json:"created_by"`
+}
+
+type auditedNote struct {
+	auditBase
+	CreatedBy int    `json:"created_by"`
+	Text      string `json:"text"`
+}
+
+func TestFromType_EmbeddedStructs(t *testing.T) {
+	s := FromType(auditedNote{})
+	data, _ := json.Marshal(s)
+	if strings.Contains(string(data), "$ref") || len(s.AllOf) != 0 {
+		t.Fatalf("expected the embedded fields inline without composition, got %s", data)
+	}
+	if s.Properties["id"] == nil || s.Properties["text"] == nil || len(s.Required) != 1 || s.Required[0] != "id" {
+		t.Errorf("expected the embedded fields promoted, got %s", data)
+	}
+	if s.Properties["created_by"].Type != "integer" {
+		t.Errorf("expected the struct's own field to shadow the promoted one, got %s", data)
+	}
+
+	composed := FromTypeWithOptions(auditedNote{}, Options{ComposeEmbedded: true})
+	if len(composed.AllOf) != 2 || composed.AllOf[0].Ref != "#/components/schemas/auditBase" || composed.AllOf[0].Definitions["auditBase"] == nil {
+		t.Errorf("expected allOf of the base component and the own properties, got %+v", composed)
+	}
+}
+
+func TestCollectDefinitions_SameNamedTypes(t *testing.T) {
+	opts := Options{ComposeEmbedded: true}
+	first := func() *Schema {
+		type Base struct {
+			ID int `json:"id"`
+		}
+		type Order struct {
+			Base
+		}
+		return FromTypeWithOptions(Order{}, opts)
+	}
+	second := func() *Schema {
+		type Base struct {
+			Code string `json:"code"`
+		}
+		type Invoice struct {
+			Base
+		}
+		return FromTypeWithOptions(Invoice{}, opts)
+	}
+
+	defs := make(map[string]*Schema)
+	order, invoice := first(), second()
+	for _, s := range []*Schema{order, invoice, first()} {
+		CollectDefinitions(s, defs)
+	}
+
+	if len(defs) != 2 || defs["Base"].Properties["id"] == nil || defs["schema.Base"].Properties["code"] == nil {
+		t.Fatalf("expected the second Base qualified with its package, got %v", defs)
+	}
+	if order.AllOf[0].Ref != "#/components/schemas/Base" || invoice.AllOf[0].Ref != "#/components/schemas/schema.Base" {
+		t.Errorf("expected the refs to follow the component names, got %s and %s", order.AllOf[0].Ref, invoice.AllOf[0].Ref)
+	}
+}
+
+type cachedPriority string
+
+type cachedTask struct {
+	Title    string         `json:"title" example:"Write docs"`
+	Priority cachedPriority `json:"priority"`
+	Tags     []string       `json:"tags"`
+}
+
+func TestFromType_Cache(t *testing.T) {
+	first := FromType(cachedTask{})
+	first.Properties["title"].Example = "changed"
+	first.Properties["tags"].Items.Type = "integer"
+
+	second := FromType(cachedTask{})
+	if second.Properties["title"].Example != "Write docs" || second.Properties["tags"].Items.Type != "string" {
+		t.Errorf("expected cached schemas to be isolated from callers, got %+v", second.Properties)
+	}
+
+	RegisterEnum(cachedPriority(""), "low", "high")
+	if enum := FromType(cachedTask{}).Properties["priority"].Enum; len(enum) != 2 {
+		t.Errorf("expected RegisterEnum to invalidate cached schemas, got enum %v", enum)
+	}
+}
+
+type benchAddress struct {
+	Street  string `json:"street" example:"Main St" description:"Street name"`
+	City    string `json:"city" validate:"required"`
+	Country string `json:"country" enum:"US,DE,ID"`
+}
+
+type benchUser struct {
+	ID        string         `json:"id" format:"uuid"`
+	Name      string         `json:"name" validate:"required,min=1"`
+	Email     string         `json:"email" swagger:"required,format=email"`
+	Addresses []benchAddress `json:"addresses"`
+	Manager   *benchUser     `json:"-"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+// BenchmarkFromType converts a shared DTO repeatedly, as BuildSpec does
+// for endpoints reusing types; compare with BenchmarkFromType_Uncached
+func BenchmarkFromType(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		FromType(benchUser{})
+	}
+}
+
+func BenchmarkFromType_Uncached(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		resetTypeCache()
+		FromType(benchUser{})
+	}
+}
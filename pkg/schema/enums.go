@@ -0,0 +1,212 @@
+package schema
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// EnumValue is an allowed value of an enum type with a human-readable
+// description and, for integer codes, the name of its constant
+type EnumValue struct {
+	Value       interface{}
+	Description string
+	// Name is emitted in x-enumNames and x-enum-varnames, which code
+	// generators use to name the constants, e.g. {Value: 1, Name: "Active"}
+	Name string
+}
+
+var (
+	enumMu       sync.RWMutex
+	enumRegistry = make(map[reflect.Type][]EnumValue)
+)
+
+// RegisterEnum registers the allowed values of a named type, e.g.
+// RegisterEnum(Status(""), StatusActive, StatusInactive)
+func RegisterEnum(t interface{}, values ...interface{}) {
+	enumValues := make([]EnumValue, len(values))
+	for i, v := range values {
+		enumValues[i] = EnumValue{Value: v}
+	}
+	RegisterEnumWithDescriptions(t, enumValues)
+}
+
+// RegisterEnumWithDescriptions registers the allowed values of a named type
+// together with a description per value, emitted as x-enum-descriptions
+func RegisterEnumWithDescriptions(t interface{}, values []EnumValue) {
+	rt := reflect.TypeOf(t)
+	if rt == nil {
+		return
+	}
+	for rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+
+	normalized := make([]EnumValue, len(values))
+	for i, v := range values {
+		v.Value = normalizeEnumValue(v.Value, rt.Kind())
+		normalized[i] = v
+	}
+	values = normalized
+
+	enumMu.Lock()
+	enumRegistry[rt] = values
+	enumMu.Unlock()
+
+	// Schemas converted before the registration lack the enum
+	resetTypeCache()
+}
+
+// RegisterEnumWithNames registers integer codes with the names of their
+// constants, e.g. RegisterEnumWithNames(Status(0), map[Status]string{0: "Unknown", 1: "Active"}).
+// Values are sorted ascending so enum and x-enumNames line up.
+func RegisterEnumWithNames[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64](t T, names map[T]string) {
+	codes := make([]T, 0, len(names))
+	for code := range names {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool { return codes[i] < codes[j] })
+
+	values := make([]EnumValue, len(codes))
+	for i, code := range codes {
+		values[i] = EnumValue{Value: code, Name: names[code]}
+	}
+	RegisterEnumWithDescriptions(t, values)
+}
+
+// normalizeEnumValue converts a registered value to a plain value of the
+// enum's kind: typed constants (Status(1)) lose their named type, so they
+// can't marshal as anything but the schema type, and strings registered for
+// numeric or boolean enums are parsed
+func normalizeEnumValue(value interface{}, kind reflect.Kind) interface{} {
+	if s, ok := value.(string); ok {
+		return convertEnumValue(s, kind)
+	}
+
+	rv := reflect.ValueOf(value)
+	if !rv.IsValid() {
+		return value
+	}
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	case reflect.Bool:
+		return rv.Bool()
+	case reflect.String:
+		return convertEnumValue(rv.String(), kind)
+	}
+	return value
+}
+
+// registeredEnum returns the values registered for a type
+func registeredEnum(t reflect.Type) ([]EnumValue, bool) {
+	enumMu.RLock()
+	defer enumMu.RUnlock()
+	values, ok := enumRegistry[t]
+	return values, ok
+}
+
+// applyEnum sets the enum, example, x-enum-descriptions and x-enumNames of
+// a schema, the extensions in the same order as the enum
+func applyEnum(schema *Schema, values []EnumValue) {
+	if len(values) == 0 {
+		return
+	}
+
+	schema.Enum = make([]interface{}, len(values))
+	descriptions := make([]string, len(values))
+	names := make([]string, len(values))
+	hasDescriptions, hasNames := false, false
+	for i, v := range values {
+		schema.Enum[i] = v.Value
+		descriptions[i] = v.Description
+		names[i] = v.Name
+		if v.Description != "" {
+			hasDescriptions = true
+		}
+		if v.Name != "" {
+			hasNames = true
+		}
+	}
+	schema.Example = values[0].Value
+
+	if hasDescriptions || hasNames {
+		if schema.Extensions == nil {
+			schema.Extensions = make(map[string]interface{})
+		}
+	}
+	if hasDescriptions {
+		schema.Extensions["x-enum-descriptions"] = descriptions
+	}
+	if hasNames {
+		// x-enumNames is read by NSwag and openapi-typescript, x-enum-varnames
+		// by openapi-generator
+		schema.Extensions["x-enumNames"] = names
+		schema.Extensions["x-enum-varnames"] = names
+	}
+}
+
+// FieldEnum returns the allowed values of a struct field, taken from an
+// enum:"a,b,c" tag or from the values registered for the field's type
+func FieldEnum(field reflect.StructField) []interface{} {
+	if tag := field.Tag.Get("enum"); tag != "" {
+		return parseEnumTag(tag, field.Type)
+	}
+
+	t := field.Type
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	values, ok := registeredEnum(t)
+	if !ok {
+		return nil
+	}
+	result := make([]interface{}, len(values))
+	for i, v := range values {
+		result[i] = v.Value
+	}
+	return result
+}
+
+// parseEnumTag splits an enum tag, converting each value to the field's kind
+func parseEnumTag(tag string, t reflect.Type) []interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	parts := strings.Split(tag, ",")
+	values := make([]interface{}, 0, len(parts))
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		values = append(values, convertEnumValue(part, t.Kind()))
+	}
+	return values
+}
+
+func convertEnumValue(value string, kind reflect.Kind) interface{} {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return n
+		}
+	case reflect.Float32, reflect.Float64:
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	case reflect.Bool:
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return value
+}
@@ -0,0 +1,86 @@
+package schema
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+	"unsafe"
+)
+
+// fuzzLeaves are the base types composed by fuzzType
+var fuzzLeaves = []reflect.Type{
+	reflect.TypeOf(""),
+	reflect.TypeOf(0),
+	reflect.TypeOf(uint8(0)),
+	reflect.TypeOf(float32(0)),
+	reflect.TypeOf(false),
+	reflect.TypeOf(complex64(0)),
+	reflect.TypeOf(complex128(0)),
+	reflect.TypeOf(make(chan int)),
+	reflect.TypeOf(func() {}),
+	reflect.TypeOf(unsafe.Pointer(nil)),
+	reflect.TypeOf((*interface{})(nil)).Elem(),
+	reflect.TypeOf(TokenRequest{}),
+}
+
+// fuzzType builds a type composition from data, consuming one byte per step
+func fuzzType(data []byte, depth int) (reflect.Type, []byte) {
+	if len(data) == 0 || depth > 6 {
+		return fuzzLeaves[0], data
+	}
+	op, data := data[0], data[1:]
+
+	switch op % 8 {
+	case 0:
+		elem, rest := fuzzType(data, depth+1)
+		return reflect.SliceOf(elem), rest
+	case 1:
+		elem, rest := fuzzType(data, depth+1)
+		return reflect.MapOf(reflect.TypeOf(""), elem), rest
+	case 2:
+		elem, rest := fuzzType(data, depth+1)
+		return reflect.PointerTo(elem), rest
+	case 3:
+		elem, rest := fuzzType(data, depth+1)
+		return reflect.ArrayOf(int(op%4), elem), rest
+	case 4:
+		elem, rest := fuzzType(data, depth+1)
+		return reflect.ChanOf(reflect.BothDir, elem), rest
+	case 5:
+		var fields []reflect.StructField
+		for i := 0; i < int(op%3)+1; i++ {
+			var elem reflect.Type
+			elem, data = fuzzType(data, depth+1)
+			fields = append(fields, reflect.StructField{
+				Name: "Field" + strconv.Itoa(i),
+				Type: elem,
+				Tag:  reflect.StructTag(`json:"field_` + strconv.Itoa(i) + `" swagger:"required"`),
+			})
+		}
+		return reflect.StructOf(fields), data
+	default:
+		return fuzzLeaves[int(op)%len(fuzzLeaves)], data
+	}
+}
+
+func FuzzFromReflectType(f *testing.F) {
+	f.Add([]byte{0, 6})
+	f.Add([]byte{5, 1, 0, 7, 4, 14})
+	f.Add([]byte{2, 3, 1, 0, 13, 5, 21, 22})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		typ, _ := fuzzType(data, 0)
+		if FromReflectType(typ) == nil {
+			t.Fatalf("nil schema for %v", typ)
+		}
+	})
+}
+
+func TestFromType_UnsupportedKinds(t *testing.T) {
+	for _, v := range []interface{}{make(chan int), func() {}, complex128(1), unsafe.Pointer(nil)} {
+		s := FromType(v)
+		if s.Type != "" || s.Properties != nil || s.Items != nil {
+			t.Errorf("expected empty schema for %T, got %+v", v, s)
+		}
+	}
+}
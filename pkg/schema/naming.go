@@ -0,0 +1,69 @@
+package schema
+
+import (
+	"strings"
+	"unicode"
+)
+
+// FieldNaming is the strategy for naming struct fields that have no json tag
+type FieldNaming string
+
+const (
+	// FieldNamingAsIs keeps the Go field name (UserID -> UserID), the default
+	FieldNamingAsIs FieldNaming = "asis"
+	// FieldNamingCamel lower-cases the first word (UserID -> userID)
+	FieldNamingCamel FieldNaming = "camel"
+	// FieldNamingSnake joins lower-cased words with underscores (UserID -> user_id)
+	FieldNamingSnake FieldNaming = "snake"
+)
+
+// Apply renames a Go field name according to the strategy
+func (n FieldNaming) Apply(name string) string {
+	switch n {
+	case FieldNamingCamel:
+		words := splitWords(name)
+		if len(words) == 0 {
+			return name
+		}
+		words[0] = strings.ToLower(words[0])
+		return strings.Join(words, "")
+	case FieldNamingSnake:
+		words := splitWords(name)
+		for i, w := range words {
+			words[i] = strings.ToLower(w)
+		}
+		return strings.Join(words, "_")
+	default:
+		return name
+	}
+}
+
+// splitWords splits a Go identifier at case changes, keeping acronyms
+// together (HTTPServerID -> HTTP, Server, ID)
+func splitWords(name string) []string {
+	runes := []rune(name)
+	var words []string
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		if runes[i] == '_' {
+			if i > start {
+				words = append(words, string(runes[start:i]))
+			}
+			start = i + 1
+			continue
+		}
+		if !unicode.IsUpper(runes[i]) || i == start {
+			continue
+		}
+		prev := runes[i-1]
+		nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+		if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	if start < len(runes) {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
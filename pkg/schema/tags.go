@@ -3,6 +3,7 @@ package schema
 import (
 	"reflect"
 	"strings"
+	"time"
 )
 
 // ParseFieldTags parses struct field tags into schema
@@ -12,6 +13,20 @@ func ParseFieldTags(field reflect.StructField, schema *Schema) {
 		schema.Example = example
 	}
 
+	// Parse examples tag (JSON Schema 2020-12, emitted by OpenAPI 3.1):
+	// examples:"a|b|c"; the first one doubles as the 3.0 example
+	if examples := field.Tag.Get("examples"); examples != "" {
+		schema.Examples = parseExamplesTag(examples, field.Type)
+		if _, ok := field.Tag.Lookup("example"); !ok && len(schema.Examples) > 0 {
+			schema.Example = schema.Examples[0]
+		}
+	}
+
+	// Parse comment tag, a $comment for spec maintainers rather than consumers
+	if comment := field.Tag.Get("comment"); comment != "" {
+		schema.Comment = comment
+	}
+
 	// Parse description tag
 	if desc := field.Tag.Get("description"); desc != "" {
 		schema.Description = desc
@@ -22,10 +37,58 @@ func ParseFieldTags(field reflect.StructField, schema *Schema) {
 		schema.Format = format
 	}
 
+	// Parse enum tag
+	if enum := field.Tag.Get("enum"); enum != "" {
+		schema.Enum = parseEnumTag(enum, field.Type)
+	}
+
 	// Parse swagger tag
 	if swagger := field.Tag.Get("swagger"); swagger != "" {
 		parseSwaggerTag(swagger, schema)
 	}
+
+	// A format override on a time.Time field needs a matching default example
+	if isTimeField(field) && schema.Example == timeExamples["date-time"] {
+		if example, ok := timeExamples[schema.Format]; ok {
+			schema.Example = example
+		}
+	}
+
+	// Document conditional requirements in the description as well, since
+	// most tools ignore the x-required-if extension
+	if cond, ok := schema.Extensions["x-required-if"].(map[string]interface{}); ok {
+		note := "Required when " + cond["field"].(string) + " is " + cond["value"].(string) + "."
+		if schema.Description != "" {
+			schema.Description += " " + note
+		} else {
+			schema.Description = note
+		}
+	}
+}
+
+// parseExamplesTag splits an examples tag on "|", converting each value to
+// the field's type like enum values
+func parseExamplesTag(tag string, t reflect.Type) []interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	var values []interface{}
+	for _, part := range strings.Split(tag, "|") {
+		if part = strings.TrimSpace(part); part != "" {
+			values = append(values, convertEnumValue(part, t.Kind()))
+		}
+	}
+	return values
+}
+
+// isTimeField reports whether the field is a time.Time or *time.Time
+func isTimeField(field reflect.StructField) bool {
+	t := field.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t == reflect.TypeOf(time.Time{})
 }
 
 func parseSwaggerTag(tag string, schema *Schema) {
@@ -34,7 +97,7 @@ func parseSwaggerTag(tag string, schema *Schema) {
 		kv := strings.SplitN(part, "=", 2)
 		key := strings.TrimSpace(kv[0])
 
-		switch key {
+		switch strings.ToLower(key) {
 		case "format":
 			if len(kv) > 1 {
 				schema.Format = kv[1]
@@ -47,13 +110,58 @@ func parseSwaggerTag(tag string, schema *Schema) {
 			if len(kv) > 1 {
 				schema.Example = kv[1]
 			}
+		case "deprecated":
+			schema.Deprecated = true
+		case "readonly":
+			schema.ReadOnly = true
+		case "writeonly":
+			schema.WriteOnly = true
+		case "requiredif":
+			// requiredIf=grant_type:refresh_token
+			if len(kv) > 1 {
+				field, value, ok := strings.Cut(kv[1], ":")
+				if ok {
+					if schema.Extensions == nil {
+						schema.Extensions = make(map[string]interface{})
+					}
+					schema.Extensions["x-required-if"] = map[string]interface{}{
+						"field": strings.TrimSpace(field),
+						"value": strings.TrimSpace(value),
+					}
+				}
+			}
 		}
 	}
 }
 
+// IsDeprecated checks if a field is marked deprecated via the swagger tag
+func IsDeprecated(field reflect.StructField) bool {
+	return hasSwaggerFlag(field, "deprecated")
+}
+
+// IsReadOnly checks if a field is server-controlled (swagger:"readOnly")
+func IsReadOnly(field reflect.StructField) bool {
+	return hasSwaggerFlag(field, "readonly")
+}
+
+// IsWriteOnly checks if a field is only sent by clients (swagger:"writeOnly")
+func IsWriteOnly(field reflect.StructField) bool {
+	return hasSwaggerFlag(field, "writeonly")
+}
+
+// hasSwaggerFlag reports whether the swagger tag contains a bare flag (case-insensitive)
+func hasSwaggerFlag(field reflect.StructField, flag string) bool {
+	for _, part := range strings.Split(field.Tag.Get("swagger"), ",") {
+		if strings.EqualFold(strings.TrimSpace(part), flag) {
+			return true
+		}
+	}
+	return false
+}
+
 // IsRequired checks if a field is required based on tags
 func IsRequired(field reflect.StructField) bool {
-	if swagger := field.Tag.Get("swagger"); strings.Contains(swagger, "required") {
+	if hasSwaggerFlag(field, "required") {
 		return true
 	}
 	if validate := field.Tag.Get("validate"); strings.Contains(validate, "required") {
@@ -1,7 +1,16 @@
 package schema
 
 import (
+	"encoding"
+	"encoding/json"
 	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
 )
 
 // ValidationError represents a schema validation error
@@ -26,10 +35,10 @@ func NewValidator() *Validator {
 func (v *Validator) Validate(schema *Schema) []ValidationError {
 	errors := []ValidationError{}
 
-	if schema.Type == "" && schema.Ref == "" {
+	if schema.Type == "" && schema.Ref == "" && len(schema.AllOf) == 0 {
 		errors = append(errors, ValidationError{
 			Path:    "type",
-			Message: "type or $ref is required",
+			Message: "type, $ref or allOf is required",
 		})
 	}
 
@@ -40,6 +49,23 @@ func (v *Validator) Validate(schema *Schema) []ValidationError {
 		})
 	}
 
+	// Examples and defaults must satisfy the schema's own constraints
+	samples := []struct {
+		path  string
+		value interface{}
+	}{{"example", schema.Example}, {"default", schema.Default}}
+	for i, example := range schema.Examples {
+		samples = append(samples, struct {
+			path  string
+			value interface{}
+		}{fmt.Sprintf("examples.%d", i), example})
+	}
+	for _, sample := range samples {
+		for _, msg := range constraintViolations(sample.value, schema) {
+			errors = append(errors, ValidationError{Path: sample.path, Message: msg})
+		}
+	}
+
 	if schema.Type == "object" && schema.Properties != nil {
 		for name, prop := range schema.Properties {
 			propErrors := v.Validate(prop)
@@ -55,50 +81,338 @@ func (v *Validator) Validate(schema *Schema) []ValidationError {
 	return errors
 }
 
-// ValidateValue validates a value against a schema
+// ValidateValue validates a value against a schema: its type, then its enum,
+// length, pattern and range constraints, one error per failing constraint.
+// Objects and arrays are validated recursively; errors inside them carry
+// JSON pointer paths such as /address/street or /items/0. Values may be
+// decoded JSON or Go values: structs (by their json names), maps, slices
+// and named types. $refs are resolved through the schema's Definitions.
 func (v *Validator) ValidateValue(value interface{}, schema *Schema) []ValidationError {
+	defs := make(map[string]*Schema)
+	CollectDefinitions(schema, defs)
+	return v.validateValue(value, schema, defs, "")
+}
+
+func (v *Validator) validateValue(value interface{}, schema *Schema, defs map[string]*Schema, path string) []ValidationError {
 	errors := []ValidationError{}
 
-	if value == nil {
+	value = indirect(value)
+	if value == nil || schema == nil {
 		return errors
 	}
 
+	if schema.Ref != "" {
+		if def, ok := defs[schema.Ref[strings.LastIndex(schema.Ref, "/")+1:]]; ok {
+			errors = append(errors, v.validateValue(value, def, defs, path)...)
+		}
+	}
+	for _, sub := range schema.AllOf {
+		errors = append(errors, v.validateValue(value, sub, defs, path)...)
+	}
+
+	rv := reflect.ValueOf(value)
 	switch schema.Type {
 	case "string":
-		if _, ok := value.(string); !ok {
+		if rv.Kind() != reflect.String {
 			errors = append(errors, ValidationError{
-				Path:    "",
+				Path:    path,
 				Message: "expected string",
 			})
 		}
 	case "integer":
-		switch value.(type) {
-		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 			// valid
+		case reflect.Float32, reflect.Float64:
+			// encoding/json decodes every number as float64
+			if n := rv.Float(); n != float64(int64(n)) {
+				errors = append(errors, ValidationError{
+					Path:    path,
+					Message: "expected integer",
+				})
+			}
 		default:
 			errors = append(errors, ValidationError{
-				Path:    "",
+				Path:    path,
 				Message: "expected integer",
 			})
 		}
 	case "number":
-		switch value.(type) {
-		case float32, float64, int, int64:
-			// valid
-		default:
+		if _, ok := numericValue(value, ""); !ok {
 			errors = append(errors, ValidationError{
-				Path:    "",
+				Path:    path,
 				Message: "expected number",
 			})
 		}
 	case "boolean":
-		if _, ok := value.(bool); !ok {
+		if rv.Kind() != reflect.Bool {
 			errors = append(errors, ValidationError{
-				Path:    "",
+				Path:    path,
 				Message: "expected boolean",
 			})
 		}
+	case "object":
+		obj, ok := objectValue(rv)
+		if !ok {
+			errors = append(errors, ValidationError{
+				Path:    path,
+				Message: "expected object",
+			})
+			break
+		}
+		errors = append(errors, v.validateObject(obj, schema, defs, path)...)
+	case "array":
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			errors = append(errors, ValidationError{
+				Path:    path,
+				Message: "expected array",
+			})
+			break
+		}
+		for i := 0; i < rv.Len(); i++ {
+			errors = append(errors, v.validateValue(rv.Index(i).Interface(), schema.Items, defs, path+"/"+strconv.Itoa(i))...)
+		}
+	}
+
+	if len(errors) > 0 {
+		return errors
+	}
+
+	for _, msg := range constraintViolations(value, schema) {
+		errors = append(errors, ValidationError{Path: path, Message: msg})
+	}
+
+	return errors
+}
+
+// validateObject checks required properties and validates each present
+// property, in name order so errors are reported deterministically
+func (v *Validator) validateObject(obj map[string]interface{}, schema *Schema, defs map[string]*Schema, path string) []ValidationError {
+	errors := []ValidationError{}
+
+	for _, name := range schema.Required {
+		if _, ok := obj[name]; !ok {
+			errors = append(errors, ValidationError{
+				Path:    path + "/" + escapePointer(name),
+				Message: "required property is missing",
+			})
+		}
+	}
+
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if value, ok := obj[name]; ok {
+			errors = append(errors, v.validateValue(value, schema.Properties[name], defs, path+"/"+escapePointer(name))...)
+		}
 	}
 
 	return errors
 }
+
+// indirect dereferences pointers and interfaces, returning nil for nil ones.
+// Values with custom JSON or text marshalling (time.Time, ...) are replaced
+// by their JSON form.
+func indirect(value interface{}) interface{} {
+	rv := reflect.ValueOf(value)
+	for {
+		if !rv.IsValid() {
+			return nil
+		}
+		if rv.CanInterface() {
+			switch m := rv.Interface().(type) {
+			case json.Marshaler:
+				if data, err := m.MarshalJSON(); err == nil {
+					var decoded interface{}
+					if json.Unmarshal(data, &decoded) == nil {
+						return decoded
+					}
+				}
+			case encoding.TextMarshaler:
+				if text, err := m.MarshalText(); err == nil {
+					return string(text)
+				}
+			}
+		}
+		if rv.Kind() != reflect.Ptr && rv.Kind() != reflect.Interface {
+			return rv.Interface()
+		}
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+}
+
+// objectValue returns the properties of a map with string keys or of a
+// struct, named as encoding/json would: by json tag, skipping "-" and
+// omitempty fields holding their zero value, with embedded structs promoted
+func objectValue(rv reflect.Value) (map[string]interface{}, bool) {
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil, false
+		}
+		obj := make(map[string]interface{}, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			obj[iter.Key().String()] = iter.Value().Interface()
+		}
+		return obj, true
+	case reflect.Struct:
+		obj := make(map[string]interface{})
+		addStructFields(obj, rv)
+		return obj, true
+	}
+	return nil, false
+}
+
+func addStructFields(obj map[string]interface{}, rv reflect.Value) {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		value := rv.Field(i)
+		if field.Anonymous && name == "" {
+			embedded := value
+			for embedded.Kind() == reflect.Ptr {
+				if embedded.IsNil() {
+					break
+				}
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				addStructFields(obj, embedded)
+				continue
+			}
+			if embedded.Kind() == reflect.Ptr && embedded.Type().Elem().Kind() == reflect.Struct {
+				// A nil embedded struct pointer contributes no fields
+				continue
+			}
+		}
+		if !field.IsExported() || !value.CanInterface() {
+			continue
+		}
+		if strings.Contains(","+opts+",", ",omitempty,") && value.IsZero() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		obj[name] = value.Interface()
+	}
+}
+
+// escapePointer escapes a property name for use in a JSON pointer (RFC 6901)
+func escapePointer(name string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
+
+var (
+	patternMu    sync.RWMutex
+	patternCache = make(map[string]*regexp.Regexp)
+)
+
+// compilePattern compiles a schema pattern once and reuses it afterwards
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	patternMu.RLock()
+	re, ok := patternCache[pattern]
+	patternMu.RUnlock()
+	if ok {
+		return re, nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	patternMu.Lock()
+	patternCache[pattern] = re
+	patternMu.Unlock()
+	return re, nil
+}
+
+// constraintViolations describes every enum, length, pattern and range
+// constraint of schema that value breaks. Numeric strings, as produced by
+// example tags, are checked as numbers on integer and number schemas.
+func constraintViolations(value interface{}, schema *Schema) []string {
+	if value == nil {
+		return nil
+	}
+
+	var violations []string
+	if len(schema.Enum) > 0 && !enumContains(schema.Enum, value) {
+		violations = append(violations, fmt.Sprintf("%v is not one of %v", value, schema.Enum))
+	}
+
+	if n, ok := numericValue(value, schema.Type); ok {
+		if schema.Minimum != nil && n < *schema.Minimum {
+			violations = append(violations, fmt.Sprintf("%v is less than the minimum %v", value, *schema.Minimum))
+		}
+		if schema.Maximum != nil && n > *schema.Maximum {
+			violations = append(violations, fmt.Sprintf("%v is greater than the maximum %v", value, *schema.Maximum))
+		}
+		return violations
+	}
+
+	str, ok := value.(string)
+	if !ok {
+		return violations
+	}
+	length := utf8.RuneCountInString(str)
+	if schema.MinLength != nil && length < *schema.MinLength {
+		violations = append(violations, fmt.Sprintf("%q is shorter than the minimum length %d", str, *schema.MinLength))
+	}
+	if schema.MaxLength != nil && length > *schema.MaxLength {
+		violations = append(violations, fmt.Sprintf("%q is longer than the maximum length %d", str, *schema.MaxLength))
+	}
+	if schema.Pattern != "" {
+		re, err := compilePattern(schema.Pattern)
+		if err != nil {
+			violations = append(violations, fmt.Sprintf("invalid pattern %q: %v", schema.Pattern, err))
+		} else if !re.MatchString(str) {
+			violations = append(violations, fmt.Sprintf("%q does not match the pattern %q", str, schema.Pattern))
+		}
+	}
+	return violations
+}
+
+// numericValue returns value as a float64 if it is a Go number, or a numeric
+// string on an integer or number schema
+func numericValue(value interface{}, schemaType string) (float64, bool) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	case reflect.String:
+		if schemaType == "integer" || schemaType == "number" {
+			n, err := strconv.ParseFloat(rv.String(), 64)
+			return n, err == nil
+		}
+	}
+	return 0, false
+}
+
+// enumContains reports whether value is one of the enum values, comparing
+// their string forms so that 1 matches "1" from an example tag
+func enumContains(enum []interface{}, value interface{}) bool {
+	for _, allowed := range enum {
+		if reflect.DeepEqual(allowed, value) || fmt.Sprint(allowed) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
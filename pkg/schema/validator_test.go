@@ -0,0 +1,159 @@
+package schema
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestValidator_ExampleConstraints(t *testing.T) {
+	maximum, maxLength := 99.0, 3
+	schema := &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"age":     {Type: "integer", Maximum: &maximum, Example: "150"},
+			"code":    {Type: "string", MaxLength: &maxLength, Pattern: "^[A-Z]+$", Example: "abcd"},
+			"status":  {Type: "string", Enum: []interface{}{"active", "inactive"}, Default: "deleted"},
+			"quality": {Type: "integer", Maximum: &maximum, Example: 42},
+		},
+	}
+
+	errs := NewValidator().Validate(schema)
+	if len(errs) != 4 {
+		t.Fatalf("expected 4 errors, got %v", errs)
+	}
+
+	var messages []string
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	all := strings.Join(messages, "\n")
+	for _, want := range []string{
+		"properties.age.example: 150 is greater than the maximum 99",
+		`properties.code.example: "abcd" is longer than the maximum length 3`,
+		`properties.code.example: "abcd" does not match the pattern "^[A-Z]+$"`,
+		"properties.status.default: deleted is not one of [active inactive]",
+	} {
+		if !strings.Contains(all, want) {
+			t.Errorf("expected %q in:\n%s", want, all)
+		}
+	}
+}
+
+func TestValidator_ValidateValue(t *testing.T) {
+	minimum, minLength, maxLength := 1.0, 3, 5
+	username := &Schema{Type: "string", MinLength: &minLength, MaxLength: &maxLength, Pattern: "^[a-z]+$"}
+	quantity := &Schema{Type: "integer", Minimum: &minimum, Enum: []interface{}{1, 5, 10}}
+
+	tests := []struct {
+		name   string
+		value  interface{}
+		schema *Schema
+		errors int
+	}{
+		{"valid string", "alice", username, 0},
+		{"short and invalid characters", "A1", username, 2},
+		{"too long", "abcdef", username, 1},
+		{"valid integer", 5, quantity, 0},
+		{"below minimum and not in enum", 0, quantity, 2},
+		{"wrong type skips constraints", "five", quantity, 1},
+	}
+
+	v := NewValidator()
+	for _, tt := range tests {
+		if errs := v.ValidateValue(tt.value, tt.schema); len(errs) != tt.errors {
+			t.Errorf("%s: expected %d errors, got %v", tt.name, tt.errors, errs)
+		}
+	}
+}
+
+func TestValidator_ValidateValueNested(t *testing.T) {
+	type Customer struct {
+		Email string `json:"email" validate:"required"`
+	}
+	type LineItem struct {
+		SKU      string `json:"sku"`
+		Quantity int    `json:"quantity"`
+	}
+	type CreateOrderRequest struct {
+		Customer Customer   `json:"customer" validate:"required"`
+		Items    []LineItem `json:"items"`
+	}
+
+	var body interface{}
+	if err := json.Unmarshal([]byte(`{
+		"customer": {},
+		"items": [{"sku": "A-1", "quantity": 2}, {"sku": "B-2", "quantity": "two"}]
+	}`), &body); err != nil {
+		t.Fatal(err)
+	}
+
+	errs := NewValidator().ValidateValue(body, FromType(CreateOrderRequest{}))
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %v", errs)
+	}
+	if errs[0].Path != "/customer/email" || errs[0].Message != "required property is missing" {
+		t.Errorf("unexpected first error: %v", errs[0])
+	}
+	if errs[1].Path != "/items/1/quantity" || errs[1].Message != "expected integer" {
+		t.Errorf("unexpected second error: %v", errs[1])
+	}
+
+	if errs := NewValidator().ValidateValue(map[string]interface{}{}, FromType(CreateOrderRequest{})); len(errs) != 1 || errs[0].Path != "/customer" {
+		t.Errorf("expected missing customer, got %v", errs)
+	}
+}
+
+type validatedBase struct {
+	ID int `json:"id" validate:"required"`
+}
+
+type validatedAccount struct {
+	validatedBase
+	Status orderStatus `json:"status"`
+	Email  string      `json:"email" validate:"required"`
+	Tags   []string    `json:"tags"`
+	Notes  *string     `json:"notes,omitempty"`
+}
+
+func TestValidator_ValidateGoValues(t *testing.T) {
+	v := NewValidator()
+	composed := FromTypeWithOptions(validatedAccount{}, Options{ComposeEmbedded: true})
+
+	tests := []struct {
+		name   string
+		value  interface{}
+		schema *Schema
+		errors int
+	}{
+		{"typed string slice", []string{"a", "b"}, &Schema{Type: "array", Items: &Schema{Type: "string"}}, 0},
+		{"typed int slice", []int{1, 2}, &Schema{Type: "array", Items: &Schema{Type: "integer"}}, 0},
+		{"typed int slice with a wrong item type", []int{1}, &Schema{Type: "array", Items: &Schema{Type: "string"}}, 1},
+		{"typed map", map[string]int{"a": 1}, &Schema{Type: "object", Properties: map[string]*Schema{"a": {Type: "integer"}}}, 0},
+		{"struct", validatedAccount{validatedBase: validatedBase{ID: 3}, Status: "pending", Email: "a@example.com", Tags: []string{"x"}}, FromType(validatedAccount{}), 0},
+		{"struct pointer", &validatedAccount{validatedBase: validatedBase{ID: 3}, Status: "shipped", Email: "a@example.com"}, FromType(validatedAccount{}), 0},
+		{"struct with a value outside the enum", validatedAccount{Status: "lost", Email: "a@example.com"}, FromType(validatedAccount{}), 1},
+		{"not an object", []string{"a"}, FromType(validatedAccount{}), 1},
+		// Both the embedded base ($ref) and the own properties expect an object
+		{"not an object, composed", []string{"a"}, composed, 2},
+	}
+
+	for _, tt := range tests {
+		if errs := v.ValidateValue(tt.value, tt.schema); len(errs) != tt.errors {
+			t.Errorf("%s: expected %d errors, got %v", tt.name, tt.errors, errs)
+		}
+	}
+
+	// The embedded base is promoted inline, or an allOf $ref when composed;
+	// its fields are validated either way
+	for _, s := range []*Schema{FromType(validatedAccount{}), composed} {
+		errs := v.ValidateValue(map[string]interface{}{"id": "three", "email": "a@example.com"}, s)
+		if len(errs) != 1 || errs[0].Path != "/id" || errs[0].Message != "expected integer" {
+			t.Errorf("expected the base's id to be checked, got %v", errs)
+		}
+		errs = v.ValidateValue(map[string]interface{}{"email": "a@example.com"}, s)
+		if len(errs) != 1 || errs[0].Path != "/id" {
+			t.Errorf("expected the base's required id, got %v", errs)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package spec
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+)
+
+// Bundle loads the spec at rootPath and inlines every external file $ref
+// (e.g. "./common.yaml#/components/schemas/Error") into a single
+// self-contained document. Internal refs of the root document ("#/...")
+// are left intact, and remote (http/https) refs are not followed.
+func Bundle(rootPath string) (map[string]interface{}, error) {
+	absRoot, err := filepath.Abs(rootPath)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &bundler{
+		root:  absRoot,
+		files: make(map[string]interface{}),
+	}
+
+	doc, err := b.load(absRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved, err := b.resolve(doc, absRoot, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result, ok := resolved.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s: root document must be an object", rootPath)
+	}
+	return result, nil
+}
+
+// bundler holds the state of a single Bundle call
+type bundler struct {
+	root  string
+	files map[string]interface{}
+}
+
+// load reads and decodes a JSON or YAML file, caching the result
+func (b *bundler) load(path string) (interface{}, error) {
+	if doc, ok := b.files[path]; ok {
+		return doc, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc interface{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &doc)
+	default:
+		err = json.Unmarshal(data, &doc)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	b.files[path] = doc
+	return doc, nil
+}
+
+// resolve walks node and replaces external refs with their targets.
+// stack holds the refs currently being expanded to detect cycles.
+func (b *bundler) resolve(node interface{}, file string, stack []string) (interface{}, error) {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		if ref, ok := v["$ref"].(string); ok {
+			return b.resolveRef(v, ref, file, stack)
+		}
+		out := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			resolved, err := b.resolve(child, file, stack)
+			if err != nil {
+				return nil, err
+			}
+			out[key] = resolved
+		}
+		return out, nil
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, child := range v {
+			resolved, err := b.resolve(child, file, stack)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = resolved
+		}
+		return out, nil
+	default:
+		return v, nil
+	}
+}
+
+func (b *bundler) resolveRef(node map[string]interface{}, ref, file string, stack []string) (interface{}, error) {
+	if strings.HasPrefix(ref, "http://") || strings.HasPrefix(ref, "https://") {
+		return node, nil
+	}
+
+	target, fragment, _ := strings.Cut(ref, "#")
+	if target == "" {
+		// Internal refs of the root document stay as they are
+		if file == b.root {
+			return node, nil
+		}
+		target = file
+	} else {
+		target = filepath.Join(filepath.Dir(file), filepath.FromSlash(target))
+	}
+
+	key := target + "#" + fragment
+	for _, seen := range stack {
+		if seen == key {
+			return nil, fmt.Errorf("circular $ref detected: %s", strings.Join(append(stack, key), " -> "))
+		}
+	}
+
+	doc, err := b.load(target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve $ref %q: %w", ref, err)
+	}
+
+	value, err := LookupPointer(doc, fragment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve $ref %q: %w", ref, err)
+	}
+
+	return b.resolve(value, target, append(stack, key))
+}
+
+// LookupPointer resolves a JSON pointer (RFC 6901) such as "/components/schemas/User" within doc
+func LookupPointer(doc interface{}, pointer string) (interface{}, error) {
+	pointer, err := url.PathUnescape(pointer)
+	if err != nil {
+		return nil, err
+	}
+	if pointer == "" || pointer == "/" {
+		return doc, nil
+	}
+
+	current := doc
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+
+		switch v := current.(type) {
+		case map[string]interface{}:
+			next, ok := v[token]
+			if !ok {
+				return nil, fmt.Errorf("pointer %q: key %q not found", pointer, token)
+			}
+			current = next
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, fmt.Errorf("pointer %q: invalid index %q", pointer, token)
+			}
+			current = v[i]
+		default:
+			return nil, fmt.Errorf("pointer %q: cannot descend into %q", pointer, token)
+		}
+	}
+
+	return current, nil
+}
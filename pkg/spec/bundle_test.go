@@ -0,0 +1,79 @@
+package spec
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestBundle_InlinesExternalRefs(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "common.yaml", `
+schemas:
+  Error:
+    type: object
+    properties:
+      code:
+        $ref: "#/schemas/Code"
+  Code:
+    type: integer
+`)
+	root := writeFile(t, dir, "openapi.json", `{
+  "openapi": "3.1.0",
+  "paths": {
+    "/users": {
+      "get": {
+        "responses": {
+          "400": {"content": {"application/json": {"schema": {"$ref": "./common.yaml#/schemas/Error"}}}},
+          "200": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/User"}}}}
+        }
+      }
+    }
+  }
+}`)
+
+	doc, err := Bundle(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	responses := doc["paths"].(map[string]interface{})["/users"].(map[string]interface{})["get"].(map[string]interface{})["responses"].(map[string]interface{})
+	schemaOf := func(code string) map[string]interface{} {
+		return responses[code].(map[string]interface{})["content"].(map[string]interface{})["application/json"].(map[string]interface{})["schema"].(map[string]interface{})
+	}
+
+	errSchema := schemaOf("400")
+	if errSchema["type"] != "object" {
+		t.Errorf("expected inlined Error schema, got %v", errSchema)
+	}
+	code := errSchema["properties"].(map[string]interface{})["code"].(map[string]interface{})
+	if code["type"] != "integer" {
+		t.Errorf("expected nested ref inside external file to be inlined, got %v", code)
+	}
+
+	if ref := schemaOf("200")["$ref"]; ref != "#/components/schemas/User" {
+		t.Errorf("expected internal ref to be kept, got %v", ref)
+	}
+}
+
+func TestBundle_CircularRef(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "a.json", `{"node": {"$ref": "b.json#/node"}}`)
+	writeFile(t, dir, "b.json", `{"node": {"$ref": "a.json#/node"}}`)
+	root := writeFile(t, dir, "openapi.json", `{"schema": {"$ref": "a.json#/node"}}`)
+
+	_, err := Bundle(root)
+	if err == nil || !strings.Contains(err.Error(), "circular") {
+		t.Fatalf("expected circular ref error, got %v", err)
+	}
+}
@@ -17,11 +17,14 @@ type Components struct {
 // Schema represents a JSON Schema
 type Schema struct {
 	Ref                  string             `json:"$ref,omitempty"`
+	Title                string             `json:"title,omitempty"`
 	Type                 string             `json:"type,omitempty"`
 	Format               string             `json:"format,omitempty"`
 	Description          string             `json:"description,omitempty"`
 	Default              any                `json:"default,omitempty"`
 	Example              any                `json:"example,omitempty"`
+	Examples             []any              `json:"examples,omitempty"`
+	Comment              string             `json:"$comment,omitempty"`
 	Enum                 []any              `json:"enum,omitempty"`
 	Properties           map[string]*Schema `json:"properties,omitempty"`
 	Required             []string           `json:"required,omitempty"`
@@ -43,6 +46,34 @@ type Schema struct {
 	ReadOnly             bool               `json:"readOnly,omitempty"`
 	WriteOnly            bool               `json:"writeOnly,omitempty"`
 	Deprecated           bool               `json:"deprecated,omitempty"`
+	Extensions           Extensions         `json:"-"`
+}
+
+// MarshalJSON serializes the schema including its extensions.
+// A nullable typed schema is written the OpenAPI 3.1 way, as a type array
+// such as ["string", "null"]; documents declaring 3.0 turn it back into
+// "nullable": true (see OpenAPI.MarshalJSON). Without a type the schema
+// already admits null, so Nullable is left out.
+func (s Schema) MarshalJSON() ([]byte, error) {
+	type schemaAlias Schema
+	alias := schemaAlias(s)
+	alias.Nullable = false
+	if s.Nullable && s.Type != "" {
+		return marshalWithExtensions(struct {
+			schemaAlias
+			Type []string `json:"type"`
+		}{alias, []string{s.Type, "null"}}, s.Extensions)
+	}
+	return marshalWithExtensions(alias, s.Extensions)
+}
+
+// WithExtension sets a specification extension on the schema
+func (s *Schema) WithExtension(key string, value any) *Schema {
+	if s.Extensions == nil {
+		s.Extensions = make(Extensions)
+	}
+	s.Extensions.Set(key, value)
+	return s
 }
 
 // Response represents an OpenAPI response
@@ -181,6 +212,24 @@ func (r *Response) WithContent(mediaType string, schema *Schema) *Response {
 	return r
 }
 
+// AddHeader adds a header to a response
+func (r *Response) AddHeader(name string, header *Header) *Response {
+	if r.Headers == nil {
+		r.Headers = make(map[string]*Header)
+	}
+	r.Headers[name] = header
+	return r
+}
+
+// AddLink adds a link to a response
+func (r *Response) AddLink(name string, link *Link) *Response {
+	if r.Links == nil {
+		r.Links = make(map[string]*Link)
+	}
+	r.Links[name] = link
+	return r
+}
+
 // NewParameter creates a new parameter
 func NewParameter(name, in string) *Parameter {
 	return &Parameter{Name: name, In: in}
@@ -224,6 +273,12 @@ func (p *Parameter) SetRequired(required bool) *Parameter {
 	return p
 }
 
+// SetDeprecated marks the parameter as deprecated
+func (p *Parameter) SetDeprecated(deprecated bool) *Parameter {
+	p.Deprecated = deprecated
+	return p
+}
+
 // WithExample sets the parameter example
 func (p *Parameter) WithExample(example any) *Parameter {
 	p.Example = example
@@ -241,6 +296,11 @@ func NewRequestBody(description string, required bool) *RequestBody {
 
 // WithJSONContent adds JSON content to a request body
 func (rb *RequestBody) WithJSONContent(schema *Schema) *RequestBody {
-	rb.Content["application/json"] = &MediaType{Schema: schema}
+	return rb.WithContent("application/json", schema)
+}
+
+// WithContent adds content of the given media type to a request body
+func (rb *RequestBody) WithContent(mediaType string, schema *Schema) *RequestBody {
+	rb.Content[mediaType] = &MediaType{Schema: schema}
 	return rb
 }
@@ -0,0 +1,52 @@
+package spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// Extensions holds specification extensions ("x-" prefixed fields)
+type Extensions map[string]any
+
+// Set adds an extension, prefixing the key with "x-" if needed
+func (e Extensions) Set(key string, value any) {
+	if !strings.HasPrefix(key, "x-") {
+		key = "x-" + key
+	}
+	e[key] = value
+}
+
+// marshalWithExtensions marshals v and appends the extensions as extra
+// top-level fields, keeping the declared field order of v intact
+func marshalWithExtensions(v any, ext Extensions) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(ext) == 0 {
+		return data, err
+	}
+
+	keys := make([]string, 0, len(ext))
+	for key := range ext {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	buf.Write(data[:len(data)-1])
+	for i, key := range keys {
+		value, err := json.Marshal(ext[key])
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 || len(data) > 2 {
+			buf.WriteByte(',')
+		}
+		name, _ := json.Marshal(key)
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
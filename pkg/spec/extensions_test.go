@@ -0,0 +1,25 @@
+package spec
+
+import "testing"
+
+func TestSchemaMarshalJSON_Extensions(t *testing.T) {
+	s := NewSchema("string").WithExtension("enum-descriptions", []string{"a"})
+
+	data, err := s.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"type":"string","x-enum-descriptions":["a"]}`
+	if string(data) != expected {
+		t.Errorf("expected %s, got %s", expected, data)
+	}
+
+	data, err = (&Schema{}).WithExtension("x-empty", true).MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"x-empty":true}` {
+		t.Errorf("unexpected output for empty schema: %s", data)
+	}
+}
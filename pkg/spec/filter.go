@@ -0,0 +1,101 @@
+package spec
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// schemaRefPattern matches refs to component schemas in marshaled JSON
+var schemaRefPattern = regexp.MustCompile(`"\$ref":"#/components/schemas/([^"]+)"`)
+
+// FilterByTags returns a copy of the spec containing only the operations
+// carrying at least one of the given tags. Unused tags are dropped from the
+// tag list and component schemas no longer referenced are pruned.
+// The original spec is not modified.
+func (o *OpenAPI) FilterByTags(tags ...string) *OpenAPI {
+	wanted := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			wanted[tag] = true
+		}
+	}
+
+	filtered := *o
+	filtered.Paths = make(map[string]*PathItem)
+	for path, item := range o.Paths {
+		if item == nil {
+			continue
+		}
+		kept := *item
+		kept.Get = filterOperation(item.Get, wanted)
+		kept.Put = filterOperation(item.Put, wanted)
+		kept.Post = filterOperation(item.Post, wanted)
+		kept.Delete = filterOperation(item.Delete, wanted)
+		kept.Options = filterOperation(item.Options, wanted)
+		kept.Head = filterOperation(item.Head, wanted)
+		kept.Patch = filterOperation(item.Patch, wanted)
+		kept.Trace = filterOperation(item.Trace, wanted)
+		if kept.Get != nil || kept.Put != nil || kept.Post != nil || kept.Delete != nil ||
+			kept.Options != nil || kept.Head != nil || kept.Patch != nil || kept.Trace != nil {
+			filtered.Paths[path] = &kept
+		}
+	}
+
+	filtered.Tags = nil
+	for _, tag := range o.Tags {
+		if wanted[tag.Name] {
+			filtered.Tags = append(filtered.Tags, tag)
+		}
+	}
+
+	if o.Components != nil {
+		components := *o.Components
+		components.Schemas = nil
+		filtered.Components = &components
+		components.Schemas = usedSchemas(&filtered, o.Components.Schemas)
+	}
+
+	return &filtered
+}
+
+func filterOperation(op *Operation, wanted map[string]bool) *Operation {
+	if op == nil {
+		return nil
+	}
+	for _, tag := range op.Tags {
+		if wanted[tag] {
+			return op
+		}
+	}
+	return nil
+}
+
+// usedSchemas returns the schemas referenced from doc, following refs
+// between schemas transitively
+func usedSchemas(doc *OpenAPI, schemas map[string]*Schema) map[string]*Schema {
+	if len(schemas) == 0 {
+		return schemas
+	}
+
+	used := make(map[string]*Schema)
+	pending := []any{doc}
+	for len(pending) > 0 {
+		data, err := json.Marshal(pending[0])
+		pending = pending[1:]
+		if err != nil {
+			continue
+		}
+		for _, match := range schemaRefPattern.FindAllSubmatch(data, -1) {
+			name := string(match[1])
+			if _, seen := used[name]; seen {
+				continue
+			}
+			if s, ok := schemas[name]; ok {
+				used[name] = s
+				pending = append(pending, s)
+			}
+		}
+	}
+	return used
+}
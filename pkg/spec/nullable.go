@@ -0,0 +1,128 @@
+package spec
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// MarshalJSON serializes the specification. Documents declaring an OpenAPI
+// 3.0.x version get their nullable type arrays rewritten to the 3.0
+// "nullable": true keyword, their schema examples arrays and $comments
+// downgraded, their webhooks moved to x-webhooks and their info summary
+// and license identifier dropped.
+func (o OpenAPI) MarshalJSON() ([]byte, error) {
+	type openAPIAlias OpenAPI
+	data, err := json.Marshal(openAPIAlias(o))
+	if err != nil || !strings.HasPrefix(o.OpenAPI, "3.0") {
+		return data, err
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	downgradeNullable(doc)
+	downgradeSchemaKeywords(doc)
+	// 3.0 has no webhooks; keep them under the extension most tools read instead
+	if webhooks, ok := doc["webhooks"]; ok {
+		doc["x-webhooks"] = webhooks
+		delete(doc, "webhooks")
+	}
+	if info, ok := doc["info"].(map[string]interface{}); ok {
+		delete(info, "summary")
+		if license, ok := info["license"].(map[string]interface{}); ok {
+			delete(license, "identifier")
+		}
+	}
+	return json.Marshal(doc)
+}
+
+// literalKeys hold user data rather than schemas and are never rewritten
+var literalKeys = map[string]bool{
+	"example":  true,
+	"examples": true,
+	"default":  true,
+	"enum":     true,
+	"const":    true,
+}
+
+// namedMaps map user-chosen names (property names, status codes such as
+// "default", media types, ...) to specification objects. Their entries are
+// walked whatever their name, so literalKeys only apply to the fields of
+// the objects themselves.
+var namedMaps = map[string]bool{
+	"properties":        true,
+	"patternProperties": true,
+	"schemas":           true,
+	"responses":         true,
+	"paths":             true,
+	"webhooks":          true,
+	"callbacks":         true,
+	"content":           true,
+	"headers":           true,
+	"encoding":          true,
+}
+
+// walkChildren calls visit with every child of a decoded object that may
+// hold schemas, skipping literal values
+func walkChildren(v map[string]interface{}, visit func(interface{})) {
+	for key, child := range v {
+		switch {
+		case namedMaps[key]:
+			entries, ok := child.(map[string]interface{})
+			if !ok {
+				visit(child)
+				continue
+			}
+			for _, entry := range entries {
+				visit(entry)
+			}
+		case !literalKeys[key]:
+			visit(child)
+		}
+	}
+}
+
+// downgradeNullable turns {"type": ["string", "null"]} into
+// {"type": "string", "nullable": true} throughout a decoded document
+func downgradeNullable(node interface{}) {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		if types, ok := v["type"].([]interface{}); ok && len(types) == 2 {
+			for i, t := range types {
+				if t == "null" {
+					v["type"] = types[1-i]
+					v["nullable"] = true
+					break
+				}
+			}
+		}
+		walkChildren(v, downgradeNullable)
+	case []interface{}:
+		for _, child := range v {
+			downgradeNullable(child)
+		}
+	}
+}
+
+// downgradeSchemaKeywords removes the JSON Schema 2020-12 keywords 3.0 does
+// not know: $comment is dropped and an examples array becomes the singular
+// example unless one is already set. Named examples of media types and
+// parameters are maps and stay untouched.
+func downgradeSchemaKeywords(node interface{}) {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		if examples, ok := v["examples"].([]interface{}); ok {
+			if _, exists := v["example"]; !exists && len(examples) > 0 {
+				v["example"] = examples[0]
+			}
+			delete(v, "examples")
+		}
+		delete(v, "$comment")
+		walkChildren(v, downgradeSchemaKeywords)
+	case []interface{}:
+		for _, child := range v {
+			downgradeSchemaKeywords(child)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package spec
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestSchemaMarshalJSON_Nullable(t *testing.T) {
+	data, err := json.Marshal(&Schema{Type: "string", Nullable: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"type":["string","null"]}` {
+		t.Errorf("unexpected 3.1 nullable output: %s", data)
+	}
+
+	data, err = json.Marshal(&Schema{Nullable: true, Description: "Any value"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"description":"Any value"}` {
+		t.Errorf("expected no nullable keyword on an untyped schema, got %s", data)
+	}
+
+	doc := NewOpenAPI(Info{Title: "Test", Version: "1.0.0"})
+	doc.OpenAPI = "3.0.3"
+	doc.AddSchema("User", &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"default": {Type: "string", Nullable: true},
+		},
+	})
+
+	data, err = json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"default":{"nullable":true,"type":"string"}`) {
+		t.Errorf("expected 3.0 nullable keyword, got %s", data)
+	}
+}
+
+func TestOpenAPIMarshalJSON_NullableInDefaultResponse(t *testing.T) {
+	doc := NewOpenAPI(Info{Title: "Test", Version: "1.0.0"})
+	doc.OpenAPI = "3.0.3"
+	op := NewOperation("Get user").AddResponse("default", NewResponse("Error").WithContent("application/json", &Schema{
+		Type:       "object",
+		Properties: map[string]*Schema{"detail": {Type: "string", Nullable: true}},
+	}))
+	doc.AddPath("/users", (&PathItem{}).SetGet(op))
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), `"null"`) || !strings.Contains(string(data), `"detail":{"nullable":true,"type":"string"}`) {
+		t.Errorf("expected the default response to be downgraded, got %s", data)
+	}
+}
+
+func TestOpenAPIMarshalJSON_SchemaKeywordsInDefaultResponse(t *testing.T) {
+	doc := NewOpenAPI(Info{Title: "Test", Version: "1.0.0"})
+	doc.OpenAPI = "3.0.3"
+	op := NewOperation("Get user").AddResponse("default", NewResponse("Error").WithContent("application/json", &Schema{
+		Type:     "string",
+		Examples: []any{"not found"},
+		Comment:  "internal note",
+	}))
+	doc.AddPath("/users", (&PathItem{}).SetGet(op))
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), `"examples"`) || strings.Contains(string(data), `"$comment"`) || !strings.Contains(string(data), `"example":"not found"`) {
+		t.Errorf("expected the default response schema to be downgraded, got %s", data)
+	}
+}
+
+func TestOpenAPIMarshalJSON_SchemaExamples(t *testing.T) {
+	doc := NewOpenAPI(Info{Title: "Test", Version: "1.0.0"})
+	doc.AddSchema("Order", &Schema{
+		Type:     "string",
+		Examples: []any{"pending", "shipped"},
+		Comment:  "internal note",
+	})
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"examples":["pending","shipped"]`) || !strings.Contains(string(data), `"$comment"`) {
+		t.Errorf("expected examples and $comment in 3.1, got %s", data)
+	}
+
+	doc.OpenAPI = "3.0.3"
+	data, err = json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), `"examples"`) || strings.Contains(string(data), `"$comment"`) {
+		t.Errorf("expected 2020-12 keywords to be removed in 3.0, got %s", data)
+	}
+	if !strings.Contains(string(data), `"example":"pending"`) {
+		t.Errorf("expected the first example as the 3.0 example, got %s", data)
+	}
+}
@@ -2,14 +2,29 @@ package spec
 
 import (
 	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
 )
 
+// SupportedVersions lists the OpenAPI versions documents can declare
+var SupportedVersions = []string{"3.0.0", "3.0.1", "3.0.2", "3.0.3", "3.0.4", "3.1.0"}
+
+// ValidateVersion reports whether version is one of SupportedVersions
+func ValidateVersion(version string) error {
+	if slices.Contains(SupportedVersions, version) {
+		return nil
+	}
+	return fmt.Errorf("unsupported OpenAPI version %q (supported: %s)", version, strings.Join(SupportedVersions, ", "))
+}
+
 // OpenAPI represents the root OpenAPI specification object
 type OpenAPI struct {
 	OpenAPI      string                `json:"openapi"`
 	Info         Info                  `json:"info"`
 	Servers      []Server              `json:"servers,omitempty"`
 	Paths        map[string]*PathItem  `json:"paths"`
+	Webhooks     map[string]*PathItem  `json:"webhooks,omitempty"`
 	Components   *Components           `json:"components,omitempty"`
 	Security     []SecurityRequirement `json:"security,omitempty"`
 	Tags         []Tag                 `json:"tags,omitempty"`
@@ -66,6 +81,15 @@ func (o *OpenAPI) AddPath(path string, item *PathItem) *OpenAPI {
 	return o
 }
 
+// AddWebhook adds a webhook, keyed by event name
+func (o *OpenAPI) AddWebhook(name string, item *PathItem) *OpenAPI {
+	if o.Webhooks == nil {
+		o.Webhooks = make(map[string]*PathItem)
+	}
+	o.Webhooks[name] = item
+	return o
+}
+
 // AddSchema adds a schema to components
 func (o *OpenAPI) AddSchema(name string, schema *Schema) *OpenAPI {
 	if o.Components == nil {
@@ -1,5 +1,7 @@
 package spec
 
+import "strings"
+
 // PathItem represents an OpenAPI path item
 type PathItem struct {
 	Ref         string       `json:"$ref,omitempty"`
@@ -19,18 +21,34 @@ type PathItem struct {
 
 // Operation represents an OpenAPI operation
 type Operation struct {
-	Tags         []string              `json:"tags,omitempty"`
-	Summary      string                `json:"summary,omitempty"`
-	Description  string                `json:"description,omitempty"`
-	ExternalDocs *ExternalDocs         `json:"externalDocs,omitempty"`
-	OperationID  string                `json:"operationId,omitempty"`
-	Parameters   []*Parameter          `json:"parameters,omitempty"`
-	RequestBody  *RequestBody          `json:"requestBody,omitempty"`
-	Responses    map[string]*Response  `json:"responses"`
-	Callbacks    map[string]*Callback  `json:"callbacks,omitempty"`
-	Deprecated   bool                  `json:"deprecated,omitempty"`
-	Security     []SecurityRequirement `json:"security,omitempty"`
-	Servers      []Server              `json:"servers,omitempty"`
+	Tags         []string             `json:"tags,omitempty"`
+	Summary      string               `json:"summary,omitempty"`
+	Description  string               `json:"description,omitempty"`
+	ExternalDocs *ExternalDocs        `json:"externalDocs,omitempty"`
+	OperationID  string               `json:"operationId,omitempty"`
+	Parameters   []*Parameter         `json:"parameters,omitempty"`
+	RequestBody  *RequestBody         `json:"requestBody,omitempty"`
+	Responses    map[string]*Response `json:"responses"`
+	Callbacks    map[string]*Callback `json:"callbacks,omitempty"`
+	Deprecated   bool                 `json:"deprecated,omitempty"`
+	// Security overrides the global requirement; a non-nil empty slice
+	// marks the operation as public and is emitted as "security": []
+	Security   []SecurityRequirement `json:"security,omitempty"`
+	Servers    []Server              `json:"servers,omitempty"`
+	Extensions Extensions            `json:"-"`
+}
+
+// MarshalJSON serializes the operation including its extensions
+func (o Operation) MarshalJSON() ([]byte, error) {
+	type operationAlias Operation
+	if o.Security != nil && len(o.Security) == 0 {
+		// omitempty would drop the empty array that opts out of global security
+		return marshalWithExtensions(struct {
+			operationAlias
+			Security []SecurityRequirement `json:"security"`
+		}{operationAlias(o), o.Security}, o.Extensions)
+	}
+	return marshalWithExtensions(operationAlias(o), o.Extensions)
 }
 
 // Callback represents an OpenAPI callback
@@ -71,6 +89,86 @@ func (p *PathItem) SetPatch(op *Operation) *PathItem {
 	return p
 }
 
+// SetHead sets the HEAD operation
+func (p *PathItem) SetHead(op *Operation) *PathItem {
+	p.Head = op
+	return p
+}
+
+// SetOptions sets the OPTIONS operation
+func (p *PathItem) SetOptions(op *Operation) *PathItem {
+	p.Options = op
+	return p
+}
+
+// SetTrace sets the TRACE operation
+func (p *PathItem) SetTrace(op *Operation) *PathItem {
+	p.Trace = op
+	return p
+}
+
+// Methods are the HTTP methods a path item can hold operations for, in
+// path item order
+var Methods = []string{"GET", "PUT", "POST", "DELETE", "OPTIONS", "HEAD", "PATCH", "TRACE"}
+
+// IsMethod reports whether a path item can hold an operation for the HTTP method
+func IsMethod(method string) bool {
+	method = strings.ToUpper(method)
+	for _, m := range Methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// SetOperation sets the operation for the given HTTP method; unknown
+// methods (see IsMethod) are ignored
+func (p *PathItem) SetOperation(method string, op *Operation) *PathItem {
+	switch strings.ToUpper(method) {
+	case "GET":
+		p.Get = op
+	case "POST":
+		p.Post = op
+	case "PUT":
+		p.Put = op
+	case "PATCH":
+		p.Patch = op
+	case "DELETE":
+		p.Delete = op
+	case "OPTIONS":
+		p.Options = op
+	case "HEAD":
+		p.Head = op
+	case "TRACE":
+		p.Trace = op
+	}
+	return p
+}
+
+// Operation returns the operation for the given HTTP method, or nil
+func (p *PathItem) Operation(method string) *Operation {
+	switch strings.ToUpper(method) {
+	case "GET":
+		return p.Get
+	case "POST":
+		return p.Post
+	case "PUT":
+		return p.Put
+	case "PATCH":
+		return p.Patch
+	case "DELETE":
+		return p.Delete
+	case "OPTIONS":
+		return p.Options
+	case "HEAD":
+		return p.Head
+	case "TRACE":
+		return p.Trace
+	}
+	return nil
+}
+
 // AddParameter adds a parameter to the path item
 func (p *PathItem) AddParameter(param *Parameter) *PathItem {
 	p.Parameters = append(p.Parameters, param)
@@ -124,12 +222,42 @@ func (o *Operation) AddResponse(code string, resp *Response) *Operation {
 	return o
 }
 
+// AddServer adds a server override to the operation
+func (o *Operation) AddServer(server Server) *Operation {
+	o.Servers = append(o.Servers, server)
+	return o
+}
+
+// AddCallback adds a callback to the operation
+func (o *Operation) AddCallback(name string, callback *Callback) *Operation {
+	if o.Callbacks == nil {
+		o.Callbacks = make(map[string]*Callback)
+	}
+	o.Callbacks[name] = callback
+	return o
+}
+
 // WithSecurity sets security requirements
 func (o *Operation) WithSecurity(requirements ...SecurityRequirement) *Operation {
 	o.Security = requirements
 	return o
 }
 
+// SetPublic opts the operation out of the global security requirement
+func (o *Operation) SetPublic() *Operation {
+	o.Security = []SecurityRequirement{}
+	return o
+}
+
+// WithExtension sets a specification extension on the operation
+func (o *Operation) WithExtension(key string, value any) *Operation {
+	if o.Extensions == nil {
+		o.Extensions = make(Extensions)
+	}
+	o.Extensions.Set(key, value)
+	return o
+}
+
 // SetDeprecated marks the operation as deprecated
 func (o *Operation) SetDeprecated(deprecated bool) *Operation {
 	o.Deprecated = deprecated
@@ -0,0 +1,167 @@
+package spec
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SchemaAt resolves a JSON pointer such as "/paths/~1users/post/requestBody"
+// or "/components/schemas/User/properties/email" to the schema it denotes.
+// Pointers that stop at a request body, response, media type or parameter
+// resolve to that object's schema (preferring application/json content).
+func (o *OpenAPI) SchemaAt(pointer string) (*Schema, error) {
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("pointer %q: must start with /", pointer)
+	}
+
+	var current any = o
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+
+		next, ok := childAt(current, token)
+		if !ok {
+			return nil, fmt.Errorf("pointer %q: %q not found", pointer, token)
+		}
+		current = next
+	}
+
+	if s := schemaOf(current); s != nil {
+		return s, nil
+	}
+	return nil, fmt.Errorf("pointer %q: does not resolve to a schema", pointer)
+}
+
+// childAt descends one pointer token into a spec object
+func childAt(node any, token string) (any, bool) {
+	switch v := node.(type) {
+	case *OpenAPI:
+		switch token {
+		case "paths":
+			return v.Paths, v.Paths != nil
+		case "components":
+			return v.Components, v.Components != nil
+		}
+	case map[string]*PathItem:
+		item, ok := v[token]
+		return item, ok && item != nil
+	case *PathItem:
+		if token == "parameters" {
+			return v.Parameters, true
+		}
+		op := v.Operation(token)
+		return op, op != nil
+	case *Operation:
+		switch token {
+		case "parameters":
+			return v.Parameters, true
+		case "requestBody":
+			return v.RequestBody, v.RequestBody != nil
+		case "responses":
+			return v.Responses, v.Responses != nil
+		}
+	case map[string]*Response:
+		resp, ok := v[token]
+		return resp, ok && resp != nil
+	case []*Parameter:
+		// Parameters are addressed by index or by name
+		if i, err := strconv.Atoi(token); err == nil {
+			if i >= 0 && i < len(v) {
+				return v[i], true
+			}
+			return nil, false
+		}
+		for _, param := range v {
+			if param != nil && param.Name == token {
+				return param, true
+			}
+		}
+	case *Parameter:
+		switch token {
+		case "schema":
+			return v.Schema, v.Schema != nil
+		case "content":
+			return v.Content, v.Content != nil
+		}
+	case *RequestBody:
+		if token == "content" {
+			return v.Content, v.Content != nil
+		}
+	case *Response:
+		if token == "content" {
+			return v.Content, v.Content != nil
+		}
+	case map[string]*MediaType:
+		media, ok := v[token]
+		return media, ok && media != nil
+	case *MediaType:
+		if token == "schema" {
+			return v.Schema, v.Schema != nil
+		}
+	case *Components:
+		if token == "schemas" {
+			return v.Schemas, v.Schemas != nil
+		}
+	case map[string]*Schema:
+		s, ok := v[token]
+		return s, ok && s != nil
+	case *Schema:
+		switch token {
+		case "properties":
+			return v.Properties, v.Properties != nil
+		case "items":
+			return v.Items, v.Items != nil
+		case "additionalProperties":
+			return v.AdditionalProperties, v.AdditionalProperties != nil
+		case "not":
+			return v.Not, v.Not != nil
+		case "allOf":
+			return v.AllOf, true
+		case "oneOf":
+			return v.OneOf, true
+		case "anyOf":
+			return v.AnyOf, true
+		}
+	case []*Schema:
+		i, err := strconv.Atoi(token)
+		if err == nil && i >= 0 && i < len(v) && v[i] != nil {
+			return v[i], true
+		}
+	}
+	return nil, false
+}
+
+// schemaOf returns the schema carried by a resolved spec object
+func schemaOf(node any) *Schema {
+	switch v := node.(type) {
+	case *Schema:
+		return v
+	case *MediaType:
+		return v.Schema
+	case *Parameter:
+		if v.Schema != nil {
+			return v.Schema
+		}
+		return contentSchema(v.Content)
+	case *RequestBody:
+		return contentSchema(v.Content)
+	case *Response:
+		return contentSchema(v.Content)
+	}
+	return nil
+}
+
+// contentSchema picks the JSON schema of a content map, or the only one present
+func contentSchema(content map[string]*MediaType) *Schema {
+	if media, ok := content["application/json"]; ok && media != nil {
+		return media.Schema
+	}
+	if len(content) == 1 {
+		for _, media := range content {
+			if media != nil {
+				return media.Schema
+			}
+		}
+	}
+	return nil
+}
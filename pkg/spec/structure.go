@@ -0,0 +1,401 @@
+package spec
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// structureSchemaJSON describes the structure of OpenAPI 3.1 documents. It
+// is a hand-written subset modelled on the official schema, not the schema
+// itself: unevaluatedProperties become additionalProperties, $dynamicRef is
+// not used and Schema Objects are only checked for valid keyword types. It
+// only uses the JSON Schema keywords structureValidator implements.
+//
+//go:embed structure-3.1.json
+var structureSchemaJSON []byte
+
+// The structure schema and its compiled patterns, loaded once; a schema that
+// fails to load makes ValidateStructure report structureErr
+var structureSchema, structurePatterns, structureErr = loadStructureSchema()
+
+// loadStructureSchema decodes the embedded schema and compiles every pattern
+// and patternProperties key it uses
+func loadStructureSchema() (map[string]interface{}, map[string]*regexp.Regexp, error) {
+	var root map[string]interface{}
+	if err := json.Unmarshal(structureSchemaJSON, &root); err != nil {
+		return nil, nil, fmt.Errorf("invalid embedded structure schema: %w", err)
+	}
+
+	patterns := make(map[string]*regexp.Regexp)
+	var compile func(node interface{}) error
+	compile = func(node interface{}) error {
+		switch v := node.(type) {
+		case map[string]interface{}:
+			var sources []string
+			if pattern, ok := v["pattern"].(string); ok {
+				sources = append(sources, pattern)
+			}
+			if props, ok := v["patternProperties"].(map[string]interface{}); ok {
+				for pattern := range props {
+					sources = append(sources, pattern)
+				}
+			}
+			for _, source := range sources {
+				re, err := regexp.Compile(source)
+				if err != nil {
+					return fmt.Errorf("invalid pattern in the embedded structure schema: %w", err)
+				}
+				patterns[source] = re
+			}
+			for _, child := range v {
+				if err := compile(child); err != nil {
+					return err
+				}
+			}
+		case []interface{}:
+			for _, child := range v {
+				if err := compile(child); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	if err := compile(root); err != nil {
+		return nil, nil, err
+	}
+	return root, patterns, nil
+}
+
+// StructureError is a structural problem in a document, located by a JSON
+// pointer such as #/paths/~1users/get/responses
+type StructureError struct {
+	Pointer string
+	Message string
+}
+
+func (e StructureError) Error() string {
+	return e.Pointer + ": " + e.Message
+}
+
+// ValidateStructure checks a marshalled OpenAPI 3.1 document against an
+// embedded subset of the official schema: required fields, allowed keys,
+// enumerated values (parameter locations, security scheme types, schema
+// types, ...) and response status codes. It is not a full meta-schema
+// validation; in particular the contents of Schema Objects beyond their
+// keyword types are not checked. Errors are sorted by pointer.
+func ValidateStructure(data []byte) []error {
+	if structureErr != nil {
+		return []error{structureErr}
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return []error{fmt.Errorf("invalid JSON: %w", err)}
+	}
+
+	v := &structureValidator{root: structureSchema, patterns: structurePatterns}
+	found := v.validate(doc, structureSchema, "#")
+	sort.SliceStable(found, func(i, j int) bool { return found[i].Pointer < found[j].Pointer })
+
+	errs := make([]error, len(found))
+	for i, err := range found {
+		errs[i] = err
+	}
+	return errs
+}
+
+// structureValidator implements the JSON Schema subset used by the
+// structure schema: $ref to #/$defs, type, enum, const, required,
+// properties, patternProperties, additionalProperties, min/maxProperties,
+// items, minItems, minimum, pattern, allOf, anyOf, oneOf, not and
+// if/then/else
+type structureValidator struct {
+	root     map[string]interface{}
+	patterns map[string]*regexp.Regexp
+}
+
+func (v *structureValidator) validate(instance, schemaNode interface{}, pointer string) []StructureError {
+	switch s := schemaNode.(type) {
+	case bool:
+		if !s {
+			return []StructureError{{pointer, "is not allowed"}}
+		}
+		return nil
+	case map[string]interface{}:
+		return v.validateObject(instance, s, pointer)
+	}
+	return nil
+}
+
+func (v *structureValidator) validateObject(instance interface{}, s map[string]interface{}, pointer string) []StructureError {
+	var errs []StructureError
+	fail := func(format string, args ...interface{}) {
+		errs = append(errs, StructureError{pointer, fmt.Sprintf(format, args...)})
+	}
+
+	if ref, ok := s["$ref"].(string); ok {
+		errs = append(errs, v.validate(instance, v.resolve(ref), pointer)...)
+	}
+
+	if types, ok := s["type"]; ok && !matchesType(instance, types) {
+		fail("expected %s, got %s", describeTypes(types), jsonType(instance))
+		// The remaining keywords assume the right type
+		return errs
+	}
+
+	if enum, ok := s["enum"].([]interface{}); ok && !containsValue(enum, instance) {
+		fail("must be one of %s, got %s", formatValues(enum), formatValue(instance))
+	}
+	if constant, ok := s["const"]; ok && !reflect.DeepEqual(constant, instance) {
+		fail("must be %s, got %s", formatValue(constant), formatValue(instance))
+	}
+
+	switch value := instance.(type) {
+	case map[string]interface{}:
+		errs = append(errs, v.validateProperties(value, s, pointer)...)
+	case []interface{}:
+		if minItems, ok := s["minItems"].(float64); ok && float64(len(value)) < minItems {
+			fail("must have at least %v items", minItems)
+		}
+		if items, ok := s["items"]; ok {
+			for i, item := range value {
+				errs = append(errs, v.validate(item, items, fmt.Sprintf("%s/%d", pointer, i))...)
+			}
+		}
+	case string:
+		if pattern, ok := s["pattern"].(string); ok && !v.regexp(pattern).MatchString(value) {
+			fail("must match %q, got %q", pattern, value)
+		}
+	case float64:
+		if minimum, ok := s["minimum"].(float64); ok && value < minimum {
+			fail("must be at least %v, got %v", minimum, value)
+		}
+	}
+
+	if allOf, ok := s["allOf"].([]interface{}); ok {
+		for _, sub := range allOf {
+			errs = append(errs, v.validate(instance, sub, pointer)...)
+		}
+	}
+	if anyOf, ok := s["anyOf"].([]interface{}); ok {
+		if matched, closest := v.matchBranches(instance, anyOf, pointer); matched == 0 {
+			errs = append(errs, closest...)
+		}
+	}
+	if oneOf, ok := s["oneOf"].([]interface{}); ok {
+		matched, closest := v.matchBranches(instance, oneOf, pointer)
+		switch {
+		case matched == 0:
+			errs = append(errs, closest...)
+		case matched > 1:
+			fail("matches %d of the alternatives %s, expected exactly one", matched, describeBranches(oneOf))
+		}
+	}
+	if not, ok := s["not"]; ok && len(v.validate(instance, not, pointer)) == 0 {
+		if required, ok := not.(map[string]interface{})["required"].([]interface{}); ok && len(not.(map[string]interface{})) == 1 {
+			fail("must not set all of %s", formatValues(required))
+		} else {
+			fail("matches a disallowed schema")
+		}
+	}
+	if cond, ok := s["if"]; ok {
+		branch := "else"
+		if len(v.validate(instance, cond, pointer)) == 0 {
+			branch = "then"
+		}
+		if sub, ok := s[branch]; ok {
+			errs = append(errs, v.validate(instance, sub, pointer)...)
+		}
+	}
+	return errs
+}
+
+func (v *structureValidator) validateProperties(obj map[string]interface{}, s map[string]interface{}, pointer string) []StructureError {
+	var errs []StructureError
+	fail := func(format string, args ...interface{}) {
+		errs = append(errs, StructureError{pointer, fmt.Sprintf(format, args...)})
+	}
+
+	if required, ok := s["required"].([]interface{}); ok {
+		for _, name := range required {
+			if _, ok := obj[name.(string)]; !ok {
+				fail("missing required property %q", name)
+			}
+		}
+	}
+	if minProperties, ok := s["minProperties"].(float64); ok && float64(len(obj)) < minProperties {
+		if minProperties == 1 {
+			fail("must not be empty")
+		} else {
+			fail("must have at least %v properties", minProperties)
+		}
+	}
+	if maxProperties, ok := s["maxProperties"].(float64); ok && float64(len(obj)) > maxProperties {
+		fail("must have at most %v properties", maxProperties)
+	}
+
+	properties, _ := s["properties"].(map[string]interface{})
+	patternProperties, _ := s["patternProperties"].(map[string]interface{})
+	additional, hasAdditional := s["additionalProperties"]
+
+	names := make([]string, 0, len(obj))
+	for name := range obj {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value := obj[name]
+		childPointer := pointer + "/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+		evaluated := false
+		if sub, ok := properties[name]; ok {
+			evaluated = true
+			errs = append(errs, v.validate(value, sub, childPointer)...)
+		}
+		for pattern, sub := range patternProperties {
+			if v.regexp(pattern).MatchString(name) {
+				evaluated = true
+				errs = append(errs, v.validate(value, sub, childPointer)...)
+			}
+		}
+		if evaluated || !hasAdditional {
+			continue
+		}
+		if allowed, ok := additional.(bool); ok && !allowed {
+			fail("unexpected property %q", name)
+			continue
+		}
+		errs = append(errs, v.validate(value, additional, childPointer)...)
+	}
+	return errs
+}
+
+// matchBranches counts the alternatives the instance satisfies and, when
+// none does, returns the errors of the closest one
+func (v *structureValidator) matchBranches(instance interface{}, branches []interface{}, pointer string) (int, []StructureError) {
+	matched := 0
+	var closest []StructureError
+	for _, branch := range branches {
+		errs := v.validate(instance, branch, pointer)
+		if len(errs) == 0 {
+			matched++
+			continue
+		}
+		if closest == nil || len(errs) < len(closest) {
+			closest = errs
+		}
+	}
+	return matched, closest
+}
+
+// resolve looks up a #/$defs/... reference in the structure schema
+func (v *structureValidator) resolve(ref string) interface{} {
+	name, ok := strings.CutPrefix(ref, "#/$defs/")
+	if !ok {
+		return v.root
+	}
+	defs, _ := v.root["$defs"].(map[string]interface{})
+	if def, ok := defs[name]; ok {
+		return def
+	}
+	return true
+}
+
+// regexp returns a pattern compiled by loadStructureSchema
+func (v *structureValidator) regexp(pattern string) *regexp.Regexp {
+	return v.patterns[pattern]
+}
+
+// jsonType names the JSON type of a decoded value
+func jsonType(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return "unknown"
+}
+
+func matchesType(instance, types interface{}) bool {
+	actual := jsonType(instance)
+	matches := func(t interface{}) bool {
+		return t == actual || (t == "number" && actual == "integer")
+	}
+	if list, ok := types.([]interface{}); ok {
+		for _, t := range list {
+			if matches(t) {
+				return true
+			}
+		}
+		return false
+	}
+	return matches(types)
+}
+
+func describeTypes(types interface{}) string {
+	if list, ok := types.([]interface{}); ok {
+		names := make([]string, len(list))
+		for i, t := range list {
+			names[i] = fmt.Sprint(t)
+		}
+		return strings.Join(names, " or ")
+	}
+	return fmt.Sprint(types)
+}
+
+// describeBranches summarizes oneOf alternatives made of required lists
+func describeBranches(branches []interface{}) string {
+	var parts []string
+	for _, branch := range branches {
+		if b, ok := branch.(map[string]interface{}); ok {
+			if required, ok := b["required"].([]interface{}); ok {
+				parts = append(parts, formatValues(required))
+			}
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, allowed := range values {
+		if reflect.DeepEqual(allowed, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func formatValues(values []interface{}) string {
+	parts := make([]string, len(values))
+	for i, value := range values {
+		parts[i] = formatValue(value)
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
+
+func formatValue(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
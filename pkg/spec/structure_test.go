@@ -0,0 +1,59 @@
+package spec
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestLoadStructureSchema(t *testing.T) {
+	if _, patterns, err := loadStructureSchema(); err != nil || len(patterns) == 0 {
+		t.Fatalf("expected the embedded structure schema and its patterns to load, got %d patterns, %v", len(patterns), err)
+	}
+}
+
+func TestValidateStructure(t *testing.T) {
+	doc := NewOpenAPI(NewInfo("API", "1.0.0"))
+	doc.AddPath("/users/{id}", NewPathItem().SetGet(&Operation{
+		Parameters: []*Parameter{{Name: "id", In: "path", Required: true, Schema: NewSchema("string")}},
+		Responses:  map[string]*Response{"200": {Description: "OK", Content: map[string]*MediaType{"application/json": {Schema: NewSchema("object")}}}},
+	}))
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errs := ValidateStructure(data); len(errs) != 0 {
+		t.Fatalf("expected a valid document, got %v", errs)
+	}
+
+	doc.AddPath("/broken", NewPathItem().SetPost(&Operation{
+		Parameters: []*Parameter{
+			{Name: "id", In: "body", Schema: NewSchema("string")},
+			{Name: "slug", In: "path", Schema: NewSchema("string")},
+		},
+		Responses: map[string]*Response{
+			"200":     {Content: map[string]*MediaType{"application/json": {Schema: NewSchema("text")}}},
+			"success": {Description: "OK"},
+		},
+	}))
+	data, err = json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var messages []string
+	for _, err := range ValidateStructure(data) {
+		messages = append(messages, err.Error())
+	}
+	got := strings.Join(messages, "\n")
+	for _, want := range []string{
+		`#/paths/~1broken/post/parameters/0/in: must be one of ["query", "header", "path", "cookie"], got "body"`,
+		`#/paths/~1broken/post/parameters/1: missing required property "required"`,
+		`#/paths/~1broken/post/responses: unexpected property "success"`,
+		`#/paths/~1broken/post/responses/200/content/application~1json/schema/type: must be one of`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in:\n%s", want, got)
+		}
+	}
+}
@@ -10,8 +10,15 @@ type ConsoleConfig struct {
 	CustomHeaders    map[string]string `json:"customHeaders,omitempty"`
 	ProxyURL         string            `json:"proxyUrl,omitempty"`
 	CORSProxy        bool              `json:"corsProxy"`
+	MaxBodyBytes     int64             `json:"maxBodyBytes"`
+	// AllowedTargets lists the base URLs (scheme and host) the proxy may
+	// forward to; requests to any other origin are rejected
+	AllowedTargets []string `json:"allowedTargets,omitempty"`
 }
 
+// DefaultMaxBodyBytes is the default request and response body limit of the proxy
+const DefaultMaxBodyBytes = 5 << 20
+
 // ConsoleOption is a functional option for ConsoleConfig
 type ConsoleOption func(*ConsoleConfig)
 
@@ -23,6 +30,7 @@ func DefaultConsoleConfig() ConsoleConfig {
 		ShowCodeSnippets: true,
 		EnabledLanguages: []string{"curl", "javascript", "go", "python", "php"},
 		CORSProxy:        false,
+		MaxBodyBytes:     DefaultMaxBodyBytes,
 	}
 }
 
@@ -82,6 +90,21 @@ func WithCORSProxy(enabled bool) ConsoleOption {
 	}
 }
 
+// WithMaxBodyBytes limits the size of proxied request and response bodies
+func WithMaxBodyBytes(n int64) ConsoleOption {
+	return func(cfg *ConsoleConfig) {
+		cfg.MaxBodyBytes = n
+	}
+}
+
+// WithAllowedTargets adds base URLs the proxy may forward to, e.g.
+// "https://api.example.com"
+func WithAllowedTargets(urls ...string) ConsoleOption {
+	return func(cfg *ConsoleConfig) {
+		cfg.AllowedTargets = append(cfg.AllowedTargets, urls...)
+	}
+}
+
 // DisableSnippets disables code snippet generation
 func DisableSnippets() ConsoleOption {
 	return func(cfg *ConsoleConfig) {
@@ -2,15 +2,21 @@ package tryit
 
 import (
 	"encoding/json"
+	"net/http"
 	"time"
 )
 
+// DefaultCorrelationHeader is the response header read into HistoryEntry.CorrelationID
+const DefaultCorrelationHeader = "X-Request-ID"
+
 // HistoryConfig configures request history
 type HistoryConfig struct {
 	Enabled    bool   `json:"enabled"`
 	MaxEntries int    `json:"maxEntries"`
 	Storage    string `json:"storage"`
 	StorageKey string `json:"storageKey"`
+	// CorrelationHeader is the response header holding the server's request ID
+	CorrelationHeader string `json:"correlationHeader,omitempty"`
 }
 
 // HistoryEntry represents a single request history entry
@@ -26,6 +32,8 @@ type HistoryEntry struct {
 	Response    string            `json:"response,omitempty"`
 	Duration    int64             `json:"duration"`
 	OperationID string            `json:"operationId,omitempty"`
+	// CorrelationID ties the console call to backend logs and traces
+	CorrelationID string `json:"correlationId,omitempty"`
 }
 
 // History manages request history
@@ -37,10 +45,11 @@ type History struct {
 // DefaultHistoryConfig returns the default history configuration
 func DefaultHistoryConfig() HistoryConfig {
 	return HistoryConfig{
-		Enabled:    true,
-		MaxEntries: 50,
-		Storage:    "localStorage",
-		StorageKey: "openswag_history",
+		Enabled:           true,
+		MaxEntries:        50,
+		Storage:           "localStorage",
+		StorageKey:        "openswag_history",
+		CorrelationHeader: DefaultCorrelationHeader,
 	}
 }
 
@@ -72,6 +81,22 @@ func (h *History) Add(entry HistoryEntry) {
 	}
 }
 
+// Record adds a completed request to the history, taking its correlation ID
+// from the configured response header
+func (h *History) Record(entry HistoryEntry, responseHeader http.Header) {
+	if entry.CorrelationID == "" {
+		entry.CorrelationID = responseHeader.Get(h.correlationHeader())
+	}
+	h.Add(entry)
+}
+
+func (h *History) correlationHeader() string {
+	if h.config.CorrelationHeader == "" {
+		return DefaultCorrelationHeader
+	}
+	return h.config.CorrelationHeader
+}
+
 // Get returns all history entries
 func (h *History) Get() []HistoryEntry {
 	return h.entries
@@ -87,6 +112,16 @@ func (h *History) GetByID(id string) (HistoryEntry, bool) {
 	return HistoryEntry{}, false
 }
 
+// GetByCorrelationID returns the entry matching a server request ID
+func (h *History) GetByCorrelationID(correlationID string) (HistoryEntry, bool) {
+	for _, entry := range h.entries {
+		if correlationID != "" && entry.CorrelationID == correlationID {
+			return entry, true
+		}
+	}
+	return HistoryEntry{}, false
+}
+
 // Clear removes all history entries
 func (h *History) Clear() {
 	h.entries = make([]HistoryEntry, 0)
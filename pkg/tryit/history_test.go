@@ -0,0 +1,22 @@
+package tryit
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestHistoryRecordCorrelationID(t *testing.T) {
+	h := NewHistory(DefaultHistoryConfig())
+
+	header := http.Header{}
+	header.Set("X-Request-ID", "req-123")
+	h.Record(HistoryEntry{Method: "GET", Path: "/users", StatusCode: 200}, header)
+
+	entry, ok := h.GetByCorrelationID("req-123")
+	if !ok {
+		t.Fatal("expected entry to be found by correlation ID")
+	}
+	if entry.Path != "/users" {
+		t.Errorf("expected /users, got %s", entry.Path)
+	}
+}
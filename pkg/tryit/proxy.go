@@ -0,0 +1,237 @@
+package tryit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// hopHeaders are connection-specific headers that must not be forwarded
+var hopHeaders = []string{
+	"Connection",
+	"Keep-Alive",
+	"Proxy-Authenticate",
+	"Proxy-Authorization",
+	"Te",
+	"Trailer",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
+// Proxy forwards Try-It requests from the docs UI to the target API.
+// The target is passed in the "url" query parameter and must share its
+// scheme and host with one of the allowed targets, so the proxy can't be
+// used to reach internal hosts.
+type Proxy struct {
+	config     ConsoleConfig
+	client     *http.Client
+	timeoutFor func(method string, target *url.URL) time.Duration
+	allowed    map[string]bool
+	allowFunc  func(target *url.URL) bool
+}
+
+// NewProxy creates a new Try-It proxy handler
+func NewProxy(config ConsoleConfig) *Proxy {
+	if config.MaxBodyBytes <= 0 {
+		config.MaxBodyBytes = DefaultMaxBodyBytes
+	}
+
+	// Timeouts are applied per request, see timeout
+	p := &Proxy{
+		config:  config,
+		allowed: make(map[string]bool),
+	}
+	p.client = &http.Client{CheckRedirect: p.checkRedirect}
+	return p.AllowTargets(config.AllowedTargets...)
+}
+
+// errRedirectNotAllowed is returned for a redirect to a target that isn't allowed
+var errRedirectNotAllowed = errors.New("redirect target not allowed")
+
+// checkRedirect only follows redirects to allowed targets, so an allowed
+// upstream can't bounce the proxy to an internal host
+func (p *Proxy) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= 10 {
+		return errors.New("stopped after 10 redirects")
+	}
+	if !p.isAllowed(req.URL) {
+		return errRedirectNotAllowed
+	}
+	return nil
+}
+
+// AllowTargets adds base URLs the proxy may forward to. URLs without a
+// scheme and host (such as relative server URLs) are ignored.
+func (p *Proxy) AllowTargets(urls ...string) *Proxy {
+	for _, raw := range urls {
+		if u, err := url.Parse(raw); err == nil && u.Scheme != "" && u.Host != "" {
+			p.allowed[origin(u)] = true
+		}
+	}
+	return p
+}
+
+// WithAllowFunc sets a function allowing further targets, checked when the
+// target's origin isn't among the allowed targets; use it for targets that
+// change at runtime
+func (p *Proxy) WithAllowFunc(fn func(target *url.URL) bool) *Proxy {
+	p.allowFunc = fn
+	return p
+}
+
+// isAllowed reports whether the proxy may forward to the target
+func (p *Proxy) isAllowed(target *url.URL) bool {
+	return p.allowed[origin(target)] || (p.allowFunc != nil && p.allowFunc(target))
+}
+
+// origin is the lowercased scheme://host of a URL
+func origin(u *url.URL) string {
+	return strings.ToLower(u.Scheme + "://" + u.Host)
+}
+
+// WithTimeouts sets a function returning the timeout of a request to the
+// given target; a zero duration falls back to ConsoleConfig.RequestTimeout
+func (p *Proxy) WithTimeouts(fn func(method string, target *url.URL) time.Duration) *Proxy {
+	p.timeoutFor = fn
+	return p
+}
+
+// timeout returns the deadline for a request, zero meaning none
+func (p *Proxy) timeout(method string, target *url.URL) time.Duration {
+	if p.timeoutFor != nil {
+		if timeout := p.timeoutFor(method, target); timeout > 0 {
+			return timeout
+		}
+	}
+	return time.Duration(p.config.RequestTimeout) * time.Millisecond
+}
+
+// ServeHTTP implements http.Handler
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	target, err := url.Parse(r.URL.Query().Get("url"))
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		http.Error(w, "Invalid target URL", http.StatusBadRequest)
+		return
+	}
+	if !p.isAllowed(target) {
+		http.Error(w, "Target URL not allowed", http.StatusForbidden)
+		return
+	}
+
+	// Read the whole body up front so oversized requests fail before anything is sent
+	var body io.Reader
+	if r.Body != nil {
+		data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, p.config.MaxBodyBytes))
+		if err != nil {
+			var maxErr *http.MaxBytesError
+			if errors.As(err, &maxErr) {
+				http.Error(w, "Request body too large", http.StatusRequestEntityTooLarge)
+				return
+			}
+			http.Error(w, "Failed to read request body", http.StatusBadRequest)
+			return
+		}
+		if len(data) > 0 {
+			body = bytes.NewReader(data)
+		}
+	}
+
+	ctx := r.Context()
+	if timeout := p.timeout(r.Method, target); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	outReq, err := http.NewRequestWithContext(ctx, r.Method, target.String(), body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	outReq.Header = r.Header.Clone()
+	for _, h := range hopHeaders {
+		outReq.Header.Del(h)
+	}
+	for key, value := range p.config.CustomHeaders {
+		outReq.Header.Set(key, value)
+	}
+
+	resp, err := p.client.Do(outReq)
+	if errors.Is(err, errRedirectNotAllowed) {
+		http.Error(w, "Redirect target not allowed", http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Upstream request failed: "+err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+
+	if isStreaming(resp) {
+		p.stream(w, resp)
+		return
+	}
+
+	// Cap the upstream response so a huge payload can't exhaust memory
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, p.config.MaxBodyBytes+1))
+	if err != nil {
+		http.Error(w, "Failed to read upstream response", http.StatusBadGateway)
+		return
+	}
+	if int64(len(respBody)) > p.config.MaxBodyBytes {
+		http.Error(w, "Upstream response too large", http.StatusBadGateway)
+		return
+	}
+
+	copyResponseHeaders(w, resp)
+	w.WriteHeader(resp.StatusCode)
+	w.Write(respBody)
+}
+
+// stream relays a streaming response chunk by chunk instead of buffering it,
+// stopping once MaxBodyBytes have been forwarded
+func (p *Proxy) stream(w http.ResponseWriter, resp *http.Response) {
+	copyResponseHeaders(w, resp)
+	w.WriteHeader(resp.StatusCode)
+
+	rc := http.NewResponseController(w)
+	body := io.LimitReader(resp.Body, p.config.MaxBodyBytes)
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := body.Read(buf)
+		if n > 0 {
+			if _, werr := w.Write(buf[:n]); werr != nil {
+				return
+			}
+			rc.Flush()
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// isStreaming reports whether an upstream response is an open-ended stream
+// such as server-sent events, which must not be buffered
+func isStreaming(resp *http.Response) bool {
+	mediaType := strings.ToLower(strings.TrimSpace(strings.Split(resp.Header.Get("Content-Type"), ";")[0]))
+	return mediaType == "text/event-stream" || mediaType == "application/x-ndjson"
+}
+
+func copyResponseHeaders(w http.ResponseWriter, resp *http.Response) {
+	for key, values := range resp.Header {
+		for _, value := range values {
+			w.Header().Add(key, value)
+		}
+	}
+	for _, h := range hopHeaders {
+		w.Header().Del(h)
+	}
+	w.Header().Del("Content-Length")
+}
@@ -0,0 +1,150 @@
+package tryit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProxy_BodyLimits(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/large" {
+			w.Write([]byte(strings.Repeat("x", 32)))
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer upstream.Close()
+
+	proxy := NewProxy(*NewConsole(WithMaxBodyBytes(16), WithAllowedTargets(upstream.URL)))
+
+	send := func(path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/proxy?url="+url.QueryEscape(upstream.URL+path), strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		proxy.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := send("/", "small"); rec.Code != http.StatusOK || rec.Body.String() != "ok" {
+		t.Errorf("expected 200 ok, got %d %q", rec.Code, rec.Body.String())
+	}
+	if rec := send("/", strings.Repeat("x", 17)); rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected 413, got %d", rec.Code)
+	}
+	if rec := send("/large", ""); rec.Code != http.StatusBadGateway {
+		t.Errorf("expected 502 for oversized response, got %d", rec.Code)
+	}
+}
+
+func TestProxy_StreamsEventStream(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Write([]byte("data: " + strings.Repeat("x", 32) + "\n\n"))
+	}))
+	defer upstream.Close()
+
+	proxy := NewProxy(*NewConsole(WithMaxBodyBytes(16), WithAllowedTargets(upstream.URL)))
+	req := httptest.NewRequest(http.MethodGet, "/proxy?url="+url.QueryEscape(upstream.URL), nil)
+	rec := httptest.NewRecorder()
+	proxy.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected streamed 200, got %d", rec.Code)
+	}
+	if rec.Body.Len() != 16 || !rec.Flushed {
+		t.Errorf("expected 16 flushed bytes, got %d (flushed=%v)", rec.Body.Len(), rec.Flushed)
+	}
+}
+
+func TestProxy_PerRequestTimeout(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		w.Write([]byte("done"))
+	}))
+	defer upstream.Close()
+
+	proxy := NewProxy(*NewConsole(WithTimeout(10), WithAllowedTargets(upstream.URL))).WithTimeouts(func(method string, target *url.URL) time.Duration {
+		if target.Path == "/reports" {
+			return time.Second
+		}
+		return 0
+	})
+
+	send := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/proxy?url="+url.QueryEscape(upstream.URL+path), nil)
+		rec := httptest.NewRecorder()
+		proxy.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := send("/users"); rec.Code != http.StatusBadGateway {
+		t.Errorf("expected the console timeout to apply, got %d", rec.Code)
+	}
+	if rec := send("/reports"); rec.Code != http.StatusOK {
+		t.Errorf("expected the endpoint timeout to apply, got %d %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestProxy_RejectsTargetsNotAllowed(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer upstream.Close()
+
+	proxy := NewProxy(*NewConsole(WithAllowedTargets("https://api.example.com")))
+
+	for _, target := range []string{upstream.URL, "http://169.254.169.254/latest/meta-data/", "http://api.example.com/users"} {
+		req := httptest.NewRequest(http.MethodGet, "/proxy?url="+url.QueryEscape(target), nil)
+		rec := httptest.NewRecorder()
+		proxy.ServeHTTP(rec, req)
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("%s: expected 403, got %d", target, rec.Code)
+		}
+	}
+
+	proxy.AllowTargets(upstream.URL + "/v1")
+	req := httptest.NewRequest(http.MethodGet, "/proxy?url="+url.QueryEscape(upstream.URL+"/users"), nil)
+	rec := httptest.NewRecorder()
+	proxy.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected an allowed origin to be proxied, got %d", rec.Code)
+	}
+}
+
+func TestProxy_RejectsRedirectsNotAllowed(t *testing.T) {
+	internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("secret"))
+	}))
+	defer internal.Close()
+
+	var upstream *httptest.Server
+	upstream = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/internal":
+			http.Redirect(w, r, internal.URL+"/meta-data", http.StatusFound)
+		case "/moved":
+			http.Redirect(w, r, upstream.URL+"/users", http.StatusMovedPermanently)
+		default:
+			w.Write([]byte("ok"))
+		}
+	}))
+	defer upstream.Close()
+
+	proxy := NewProxy(*NewConsole(WithAllowedTargets(upstream.URL)))
+	send := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/proxy?url="+url.QueryEscape(upstream.URL+path), nil)
+		rec := httptest.NewRecorder()
+		proxy.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := send("/internal"); rec.Code != http.StatusForbidden || strings.Contains(rec.Body.String(), "secret") {
+		t.Errorf("expected the redirect to a non-allowed host to be refused, got %d %q", rec.Code, rec.Body.String())
+	}
+	if rec := send("/moved"); rec.Code != http.StatusOK || rec.Body.String() != "ok" {
+		t.Errorf("expected a redirect within the allowed origin to be followed, got %d %q", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,36 @@
+package snippets
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestHMACSigner(t *testing.T) {
+	req := Request{
+		Method: "post",
+		URL:    "https://api.example.com/orders",
+		Body:   `{"id":1}`,
+	}
+
+	signed, err := req.WithAuth(NewHMACSigner("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte("POST\n/orders\n" + `{"id":1}`))
+	expected := hex.EncodeToString(mac.Sum(nil))
+
+	if signed.Headers["X-Signature"] != expected {
+		t.Errorf("expected signature %s, got %s", expected, signed.Headers["X-Signature"])
+	}
+	if req.Headers != nil {
+		t.Error("expected original request to be left untouched")
+	}
+	if snippet := NewCurlGenerator().Generate(signed); !strings.Contains(snippet, "X-Signature: "+expected) {
+		t.Errorf("expected curl snippet to carry the signature, got %s", snippet)
+	}
+}
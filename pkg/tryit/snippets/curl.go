@@ -38,7 +38,11 @@ func (g *CurlGenerator) Generate(req Request) string {
 
 	// Body
 	if req.Body != "" {
-		escapedBody := strings.ReplaceAll(req.Body, "'", "'\\''")
+		body := req.Body
+		if prettyBody, ok := prettyJSON(req.Body, "  ", "  "); ok {
+			body = prettyBody
+		}
+		escapedBody := strings.ReplaceAll(body, "'", "'\\''")
 		parts = append(parts, fmt.Sprintf("-d '%s'", escapedBody))
 	}
 
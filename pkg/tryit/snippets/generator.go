@@ -1,8 +1,13 @@
 package snippets
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
 	"net/url"
 	"strings"
+
+	"github.com/andrianprasetya/open-swag-go/pkg/tryit"
 )
 
 // Request represents an HTTP request for snippet generation
@@ -12,6 +17,42 @@ type Request struct {
 	Headers     map[string]string `json:"headers"`
 	Body        string            `json:"body"`
 	QueryParams map[string]string `json:"queryParams"`
+	// Examples holds named example bodies for the operation
+	Examples map[string]string `json:"examples,omitempty"`
+}
+
+// WithExample returns a copy of the request whose body is the named example.
+// The request is returned unchanged if the example does not exist.
+func (r Request) WithExample(name string) Request {
+	if body, ok := r.Examples[name]; ok {
+		r.Body = body
+	}
+	return r
+}
+
+// WithCorrelationID returns a copy of the request carrying a freshly generated
+// correlation header (X-Request-ID when header is empty), so the snippet's
+// call can be found in server logs
+func (r Request) WithCorrelationID(header string) Request {
+	if header == "" {
+		header = tryit.DefaultCorrelationHeader
+	}
+	headers := make(map[string]string, len(r.Headers)+1)
+	for key, value := range r.Headers {
+		headers[key] = value
+	}
+	headers[header] = newCorrelationID()
+	r.Headers = headers
+	return r
+}
+
+// newCorrelationID returns a random 128-bit hex identifier
+func newCorrelationID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
 }
 
 // Generator is the interface for code snippet generators
@@ -55,6 +96,11 @@ func (m *Manager) Generate(language string, req Request) (string, bool) {
 	return gen.Generate(req), true
 }
 
+// GenerateExample creates a snippet for the given language using a named example as body
+func (m *Manager) GenerateExample(language string, req Request, example string) (string, bool) {
+	return m.Generate(language, req.WithExample(example))
+}
+
 // GenerateAll creates snippets for all registered languages
 func (m *Manager) GenerateAll(req Request) map[string]string {
 	result := make(map[string]string)
@@ -92,6 +138,19 @@ func buildQueryString(params map[string]string) string {
 	return values.Encode()
 }
 
+// prettyJSON re-indents body if it is valid JSON
+func prettyJSON(body, prefix, indent string) (string, bool) {
+	var bodyObj interface{}
+	if err := json.Unmarshal([]byte(body), &bodyObj); err != nil {
+		return "", false
+	}
+	pretty, err := json.MarshalIndent(bodyObj, prefix, indent)
+	if err != nil {
+		return "", false
+	}
+	return string(pretty), true
+}
+
 // escapeString escapes special characters in a string
 func escapeString(s string, quote rune) string {
 	var result strings.Builder
@@ -0,0 +1,122 @@
+package snippets
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRequest_WithExample(t *testing.T) {
+	req := Request{
+		Method: "POST",
+		URL:    "https://api.example.com/users",
+		Body:   `{"name":"default"}`,
+		Examples: map[string]string{
+			"admin": `{"name":"root","role":"admin"}`,
+		},
+	}
+
+	tests := []struct {
+		name    string
+		example string
+		body    string
+	}{
+		{"named example", "admin", `{"name":"root","role":"admin"}`},
+		{"unknown example keeps the body", "guest", `{"name":"default"}`},
+	}
+	for _, tt := range tests {
+		if got := req.WithExample(tt.example).Body; got != tt.body {
+			t.Errorf("%s: expected body %s, got %s", tt.name, tt.body, got)
+		}
+	}
+	if req.Body != `{"name":"default"}` {
+		t.Errorf("expected WithExample not to modify the request, got %s", req.Body)
+	}
+
+	snippet, ok := NewManager().GenerateExample("curl", req, "admin")
+	if !ok || !strings.Contains(snippet, `"role": "admin"`) {
+		t.Errorf("expected the admin example in the snippet, got %q", snippet)
+	}
+}
+
+func TestGenerators_JSONBody(t *testing.T) {
+	// Compact and unordered on input; every language renders it indented
+	// with keys in the same order
+	req := Request{Method: "POST", URL: "https://api.example.com/orders", Body: `{"qty":2,"gift":true,"note":null}`}
+
+	tests := []struct {
+		language string
+		want     []string
+	}{
+		{"curl", []string{"-d '{\n    \"gift\": true,\n    \"note\": null,\n    \"qty\": 2\n  }'"}},
+		{"javascript", []string{"body: JSON.stringify({\n    \"gift\": true,\n    \"note\": null,\n    \"qty\": 2\n  })"}},
+		{"go", []string{"strings.NewReader(`{\n\t\t\"gift\": true,\n\t\t\"note\": null,\n\t\t\"qty\": 2\n\t}`)"}},
+		{"python", []string{"data = {\n    'gift': True,\n    'note': None,\n    'qty': 2\n}", "json=data"}},
+	}
+
+	m := NewManager()
+	for _, tt := range tests {
+		snippet, ok := m.Generate(tt.language, req)
+		if !ok {
+			t.Fatalf("%s: generator not registered", tt.language)
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(snippet, want) {
+				t.Errorf("%s: expected %q in:\n%s", tt.language, want, snippet)
+			}
+		}
+	}
+}
+
+func TestGenerators_NonJSONBody(t *testing.T) {
+	req := Request{Method: "POST", URL: "https://api.example.com/login", Body: "user=o'neil&remember=1"}
+
+	tests := []struct {
+		language string
+		want     string
+	}{
+		{"curl", `-d 'user=o'\''neil&remember=1'`},
+		{"javascript", `body: 'user=o\'neil&remember=1'`},
+		{"go", "strings.NewReader(`user=o'neil&remember=1`)"},
+		{"python", `data = 'user=o\'neil&remember=1'`},
+	}
+
+	m := NewManager()
+	for _, tt := range tests {
+		snippet, _ := m.Generate(tt.language, req)
+		if !strings.Contains(snippet, tt.want) {
+			t.Errorf("%s: expected the raw body %q in:\n%s", tt.language, tt.want, snippet)
+		}
+	}
+}
+
+func TestRequest_WithCorrelationID(t *testing.T) {
+	req := Request{Method: "GET", URL: "https://api.example.com/users", Headers: map[string]string{"Accept": "application/json"}}
+
+	tagged := req.WithCorrelationID("")
+	id := tagged.Headers["X-Request-ID"]
+	if len(id) != 32 || tagged.Headers["Accept"] != "application/json" {
+		t.Fatalf("expected a 128-bit X-Request-ID next to the existing headers, got %v", tagged.Headers)
+	}
+	if len(req.Headers) != 1 {
+		t.Errorf("expected WithCorrelationID not to modify the request, got %v", req.Headers)
+	}
+	if other := req.WithCorrelationID("X-Correlation-ID").Headers["X-Correlation-ID"]; other == "" || other == id {
+		t.Errorf("expected a fresh ID under the custom header, got %q", other)
+	}
+
+	tests := []struct {
+		language string
+		want     string
+	}{
+		{"curl", "-H 'X-Request-ID: " + id + "'"},
+		{"javascript", "'X-Request-ID': '" + id + "'"},
+		{"go", `req.Header.Set("X-Request-ID", "` + id + `")`},
+	}
+	m := NewManager()
+	for _, tt := range tests {
+		snippet, _ := m.Generate(tt.language, tagged)
+		if !strings.Contains(snippet, tt.want) {
+			t.Errorf("%s: expected %q in:\n%s", tt.language, tt.want, snippet)
+		}
+	}
+}
@@ -37,7 +37,11 @@ func (g *GoGenerator) Generate(req Request) string {
 
 	// Body
 	if req.Body != "" {
-		escapedBody := strings.ReplaceAll(req.Body, "`", "` + \"`\" + `")
+		body := req.Body
+		if prettyBody, ok := prettyJSON(req.Body, "\t", "\t"); ok {
+			body = prettyBody
+		}
+		escapedBody := strings.ReplaceAll(body, "`", "` + \"`\" + `")
 		lines = append(lines, fmt.Sprintf("\tbody := strings.NewReader(`%s`)", escapedBody))
 		lines = append(lines, fmt.Sprintf("\treq, err := http.NewRequest(\"%s\", \"%s\", body)", req.Method, url))
 	} else {
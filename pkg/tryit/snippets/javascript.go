@@ -1,7 +1,6 @@
 package snippets
 
 import (
-	"encoding/json"
 	"fmt"
 	"strings"
 )
@@ -39,10 +38,8 @@ func (g *JavaScriptGenerator) Generate(req Request) string {
 
 	// Body
 	if req.Body != "" {
-		var bodyObj interface{}
-		if err := json.Unmarshal([]byte(req.Body), &bodyObj); err == nil {
-			prettyBody, _ := json.MarshalIndent(bodyObj, "  ", "  ")
-			lines = append(lines, fmt.Sprintf("  body: JSON.stringify(%s)", string(prettyBody)))
+		if prettyBody, ok := prettyJSON(req.Body, "  ", "  "); ok {
+			lines = append(lines, fmt.Sprintf("  body: JSON.stringify(%s)", prettyBody))
 		} else {
 			lines = append(lines, fmt.Sprintf("  body: '%s'", strings.ReplaceAll(req.Body, "'", "\\'")))
 		}
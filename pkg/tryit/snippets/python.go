@@ -1,7 +1,6 @@
 package snippets
 
 import (
-	"encoding/json"
 	"fmt"
 	"strings"
 )
@@ -41,10 +40,8 @@ func (g *PythonGenerator) Generate(req Request) string {
 
 	// Body
 	if req.Body != "" {
-		var bodyObj interface{}
-		if err := json.Unmarshal([]byte(req.Body), &bodyObj); err == nil {
-			prettyBody, _ := json.MarshalIndent(bodyObj, "", "    ")
-			bodyStr := strings.ReplaceAll(string(prettyBody), "\"", "'")
+		if prettyBody, ok := prettyJSON(req.Body, "", "    "); ok {
+			bodyStr := strings.ReplaceAll(prettyBody, "\"", "'")
 			bodyStr = strings.ReplaceAll(bodyStr, "null", "None")
 			bodyStr = strings.ReplaceAll(bodyStr, "true", "True")
 			bodyStr = strings.ReplaceAll(bodyStr, "false", "False")
@@ -11,6 +11,7 @@ const (
 	BreakingTypeChanged        BreakingChangeType = "type_changed"
 	BreakingRequestBodyRemoved BreakingChangeType = "request_body_removed"
 	BreakingSecurityAdded      BreakingChangeType = "security_added"
+	BreakingWebhookRemoved     BreakingChangeType = "webhook_removed"
 )
 
 // BreakingChangeRule defines a rule for detecting breaking changes
@@ -49,6 +50,11 @@ func DefaultBreakingRules() []BreakingChangeRule {
 			Description: "Changing a field type breaks serialization",
 			Severity:    "error",
 		},
+		{
+			Type:        BreakingWebhookRemoved,
+			Description: "Removing a webhook breaks consumers relying on the event",
+			Severity:    "error",
+		},
 	}
 }
 
@@ -62,6 +68,7 @@ func IsBreaking(changeType BreakingChangeType) bool {
 		BreakingTypeChanged:        true,
 		BreakingRequestBodyRemoved: true,
 		BreakingSecurityAdded:      true,
+		BreakingWebhookRemoved:     true,
 	}
 	return breakingTypes[changeType]
 }
@@ -0,0 +1,220 @@
+package versioning
+
+import (
+	"fmt"
+	"sort"
+)
+
+// DeprecationKind is what a Deprecation refers to
+type DeprecationKind string
+
+const (
+	DeprecatedOperation DeprecationKind = "operation"
+	DeprecatedParameter DeprecationKind = "parameter"
+	DeprecatedField     DeprecationKind = "field"
+)
+
+// Deprecation is a deprecated operation, parameter or schema field. Fields
+// of component schemas have the schema's pointer as Path and no Method;
+// inline request and response fields are named after their location, such
+// as requestBody.email or responses.200.items[].id.
+type Deprecation struct {
+	Kind   DeprecationKind `json:"kind"`
+	Path   string          `json:"path"`
+	Method string          `json:"method,omitempty"`
+	Name   string          `json:"name,omitempty"`
+	Reason string          `json:"reason,omitempty"`
+	Sunset string          `json:"sunset,omitempty"`
+}
+
+// DeprecationReport lists everything deprecated in a spec, sorted by path,
+// method and name. Reasons and sunset dates come from the
+// x-deprecation-reason and x-sunset extensions.
+func DeprecationReport(spec map[string]interface{}) []Deprecation {
+	report := []Deprecation{}
+
+	for _, key := range []string{"paths", "webhooks"} {
+		for path, methods := range getPathItems(spec, key) {
+			for method, op := range methods {
+				if isDeprecated(op) {
+					report = append(report, newDeprecation(DeprecatedOperation, path, method, "", op))
+				}
+				for name, param := range getParameters(op) {
+					if isDeprecated(param) {
+						report = append(report, newDeprecation(DeprecatedParameter, path, method, name, param))
+					}
+				}
+				// Component schemas are reported once below, so $refs are not followed
+				for name, field := range deprecatedFields(op) {
+					report = append(report, newDeprecation(DeprecatedField, path, method, name, field))
+				}
+			}
+		}
+	}
+
+	if components, ok := spec["components"].(map[string]interface{}); ok {
+		if schemas, ok := components["schemas"].(map[string]interface{}); ok {
+			for schemaName, s := range schemas {
+				schema, ok := s.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				path := "#/components/schemas/" + schemaName
+				walkDeprecatedFields(schema, "", func(name string, field map[string]interface{}) {
+					report = append(report, newDeprecation(DeprecatedField, path, "", name, field))
+				})
+			}
+		}
+	}
+
+	sort.Slice(report, func(i, j int) bool {
+		a, b := report[i], report[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		if a.Method != b.Method {
+			return a.Method < b.Method
+		}
+		return a.Name < b.Name
+	})
+	return report
+}
+
+func newDeprecation(kind DeprecationKind, path, method, name string, node map[string]interface{}) Deprecation {
+	reason, _ := node["x-deprecation-reason"].(string)
+	sunset, _ := node["x-sunset"].(string)
+	return Deprecation{Kind: kind, Path: path, Method: method, Name: name, Reason: reason, Sunset: sunset}
+}
+
+// newDeprecations reports the operation, parameters and body fields that are
+// deprecated in newOp but were not in oldOp. None of them are breaking.
+func newDeprecations(path, method string, oldOp, newOp map[string]interface{}) []Change {
+	changes := []Change{}
+	deprecated := func(what string, node map[string]interface{}) {
+		description := what + " deprecated"
+		if sunset, ok := node["x-sunset"].(string); ok && sunset != "" {
+			description += fmt.Sprintf(" (sunset %s)", sunset)
+		}
+		changes = append(changes, Change{
+			Type:        ChangeModified,
+			Path:        path,
+			Method:      method,
+			Description: description,
+			IsBreaking:  false,
+		})
+	}
+
+	if isDeprecated(newOp) && !isDeprecated(oldOp) {
+		deprecated("Operation", newOp)
+	}
+
+	oldParams := getParameters(oldOp)
+	newParams := getParameters(newOp)
+	for _, name := range sortedKeys(newParams) {
+		if old, exists := oldParams[name]; exists && isDeprecated(newParams[name]) && !isDeprecated(old) {
+			deprecated(fmt.Sprintf("Parameter '%s'", name), newParams[name])
+		}
+	}
+
+	oldFields := deprecatedFields(oldOp)
+	newFields := deprecatedFields(newOp)
+	for _, name := range sortedKeys(newFields) {
+		if _, exists := oldFields[name]; !exists {
+			deprecated(fmt.Sprintf("Field '%s'", name), newFields[name])
+		}
+	}
+
+	return changes
+}
+
+// deprecatedFields collects the deprecated fields of an operation's request
+// body and response schemas, keyed by their location
+func deprecatedFields(op map[string]interface{}) map[string]map[string]interface{} {
+	fields := make(map[string]map[string]interface{})
+	collect := func(prefix string, body interface{}) {
+		if schema := firstSchema(body); schema != nil {
+			walkDeprecatedFields(schema, prefix, func(name string, field map[string]interface{}) {
+				fields[name] = field
+			})
+		}
+	}
+
+	collect("requestBody", op["requestBody"])
+	if responses, ok := op["responses"].(map[string]interface{}); ok {
+		for code, resp := range responses {
+			collect("responses."+code, resp)
+		}
+	}
+	return fields
+}
+
+// firstSchema returns the schema of the first media type, in name order, of a
+// request body or response
+func firstSchema(body interface{}) map[string]interface{} {
+	bodyMap, ok := body.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	content, ok := bodyMap["content"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	for _, mediaType := range sortedKeys(content) {
+		if mt, ok := content[mediaType].(map[string]interface{}); ok {
+			if schema, ok := mt["schema"].(map[string]interface{}); ok {
+				return schema
+			}
+		}
+	}
+	return nil
+}
+
+// walkDeprecatedFields calls visit for every deprecated property below
+// schema, descending into nested objects, array items and compositions.
+// Unresolved $refs are not followed, which also stops at recursive schemas.
+func walkDeprecatedFields(schema map[string]interface{}, prefix string, visit func(name string, field map[string]interface{})) {
+	if props, ok := schema["properties"].(map[string]interface{}); ok {
+		for name, p := range props {
+			prop, ok := p.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			fieldName := name
+			if prefix != "" {
+				fieldName = prefix + "." + name
+			}
+			if isDeprecated(prop) {
+				visit(fieldName, prop)
+			}
+			walkDeprecatedFields(prop, fieldName, visit)
+		}
+	}
+
+	if items, ok := schema["items"].(map[string]interface{}); ok {
+		walkDeprecatedFields(items, prefix+"[]", visit)
+	}
+
+	for _, key := range []string{"allOf", "oneOf", "anyOf"} {
+		if subs, ok := schema[key].([]interface{}); ok {
+			for _, s := range subs {
+				if sub, ok := s.(map[string]interface{}); ok {
+					walkDeprecatedFields(sub, prefix, visit)
+				}
+			}
+		}
+	}
+}
+
+func isDeprecated(node map[string]interface{}) bool {
+	deprecated, _ := node["deprecated"].(bool)
+	return deprecated
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
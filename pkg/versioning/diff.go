@@ -1,9 +1,9 @@
 package versioning
 
 import (
-	"encoding/json"
 	"fmt"
-	"os"
+
+	"github.com/andrianprasetya/open-swag-go/pkg/spec"
 )
 
 // ChangeType represents the type of change
@@ -22,6 +22,8 @@ type Change struct {
 	Method      string     `json:"method,omitempty"`
 	Description string     `json:"description"`
 	IsBreaking  bool       `json:"isBreaking"`
+	// Field holds the property-level detail of schema changes
+	Field *FieldChange `json:"field,omitempty"`
 }
 
 // BreakingChange represents a breaking change with migration info
@@ -37,6 +39,9 @@ type Summary struct {
 	AddedEndpoints    int `json:"addedEndpoints"`
 	RemovedEndpoints  int `json:"removedEndpoints"`
 	ModifiedEndpoints int `json:"modifiedEndpoints"`
+	AddedWebhooks     int `json:"addedWebhooks"`
+	RemovedWebhooks   int `json:"removedWebhooks"`
+	ModifiedWebhooks  int `json:"modifiedWebhooks"`
 	BreakingChanges   int `json:"breakingChanges"`
 }
 
@@ -47,6 +52,7 @@ type Diff struct {
 	Changes    []Change         `json:"changes"`
 	Breaking   []BreakingChange `json:"breaking"`
 	Summary    Summary          `json:"summary"`
+	Warnings   []string         `json:"warnings,omitempty"`
 }
 
 // Differ compares OpenAPI specs
@@ -81,6 +87,16 @@ func (d *Differ) Compare(oldSpec, newSpec map[string]interface{}) (*Diff, error)
 		Breaking:   []BreakingChange{},
 	}
 
+	// Dereference local $refs so components are compared by their content
+	oldSpec, oldNotes := ResolveRefs(oldSpec)
+	newSpec, newNotes := ResolveRefs(newSpec)
+	for _, note := range oldNotes {
+		diff.Warnings = append(diff.Warnings, "old spec: "+note)
+	}
+	for _, note := range newNotes {
+		diff.Warnings = append(diff.Warnings, "new spec: "+note)
+	}
+
 	oldPaths := getPaths(oldSpec)
 	newPaths := getPaths(newSpec)
 
@@ -153,9 +169,88 @@ func (d *Differ) Compare(oldSpec, newSpec map[string]interface{}) (*Diff, error)
 		}
 	}
 
+	d.compareWebhooks(diff, getWebhooks(oldSpec), getWebhooks(newSpec))
+
 	return diff, nil
 }
 
+// compareWebhooks reports added, removed and modified OpenAPI 3.1 webhooks
+func (d *Differ) compareWebhooks(diff *Diff, oldHooks, newHooks map[string]map[string]map[string]interface{}) {
+	// Find added webhooks
+	for name, methods := range newHooks {
+		oldMethods, exists := oldHooks[name]
+		for method := range methods {
+			if !exists || oldMethods[method] == nil {
+				diff.Changes = append(diff.Changes, Change{
+					Type:        ChangeAdded,
+					Path:        name,
+					Method:      method,
+					Description: fmt.Sprintf("New webhook: %s %s", method, name),
+					IsBreaking:  false,
+				})
+				diff.Summary.AddedWebhooks++
+			}
+		}
+	}
+
+	// Find removed webhooks (breaking for event consumers)
+	for name, methods := range oldHooks {
+		newMethods, exists := newHooks[name]
+		for method := range methods {
+			if !exists || newMethods[method] == nil {
+				diff.Changes = append(diff.Changes, Change{
+					Type:        ChangeRemoved,
+					Path:        name,
+					Method:      method,
+					Description: fmt.Sprintf("Removed webhook: %s %s", method, name),
+					IsBreaking:  true,
+				})
+				diff.Breaking = append(diff.Breaking, BreakingChange{
+					Path:      name,
+					Method:    method,
+					Reason:    "Webhook removed",
+					Migration: "Stop relying on this event or subscribe to its replacement",
+				})
+				diff.Summary.RemovedWebhooks++
+				diff.Summary.BreakingChanges++
+			}
+		}
+	}
+
+	// Find modified webhooks
+	for name, oldMethods := range oldHooks {
+		newMethods, exists := newHooks[name]
+		if !exists {
+			continue
+		}
+		for method, oldOp := range oldMethods {
+			newOp, methodExists := newMethods[method]
+			if !methodExists {
+				continue
+			}
+
+			changes := d.compareOperations(name, method, oldOp, newOp)
+			diff.Changes = append(diff.Changes, changes...)
+
+			for _, change := range changes {
+				if change.IsBreaking {
+					diff.Summary.BreakingChanges++
+					diff.Breaking = append(diff.Breaking, BreakingChange{
+						Path:      name,
+						Method:    method,
+						Reason:    change.Description,
+						Migration: getMigrationGuide(change),
+					})
+				}
+			}
+
+			if len(changes) > 0 {
+				diff.Summary.ModifiedWebhooks++
+			}
+		}
+	}
+}
+
 func (d *Differ) compareOperations(path, method string, oldOp, newOp map[string]interface{}) []Change {
 	changes := []Change{}
 
@@ -248,6 +343,13 @@ func (d *Differ) compareOperations(path, method string, oldOp, newOp map[string]
 		}
 	}
 
+	// Property-level changes of the request and response schemas
+	changes = append(changes, fieldChanges(path, method, oldOp, newOp)...)
+
+	// Newly deprecated operations, parameters and fields are reported so
+	// consumers hear about them before they go away
+	changes = append(changes, newDeprecations(path, method, oldOp, newOp)...)
+
 	return changes
 }
 
@@ -258,17 +360,7 @@ func (d *Diff) HasBreakingChanges() bool {
 
 // Helper functions
 func loadSpec(path string) (map[string]interface{}, error) {
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return nil, err
-	}
-
-	var spec map[string]interface{}
-	if err := json.Unmarshal(data, &spec); err != nil {
-		return nil, err
-	}
-
-	return spec, nil
+	return spec.Bundle(path)
 }
 
 func getVersion(spec map[string]interface{}) string {
@@ -281,9 +373,18 @@ func getVersion(spec map[string]interface{}) string {
 }
 
 func getPaths(spec map[string]interface{}) map[string]map[string]map[string]interface{} {
+	return getPathItems(spec, "paths")
+}
+
+func getWebhooks(spec map[string]interface{}) map[string]map[string]map[string]interface{} {
+	return getPathItems(spec, "webhooks")
+}
+
+// getPathItems collects operations from a map of path items such as "paths" or "webhooks"
+func getPathItems(spec map[string]interface{}, key string) map[string]map[string]map[string]interface{} {
 	result := make(map[string]map[string]map[string]interface{})
 
-	if paths, ok := spec["paths"].(map[string]interface{}); ok {
+	if paths, ok := spec[key].(map[string]interface{}); ok {
 		for path, methods := range paths {
 			result[path] = make(map[string]map[string]interface{})
 			if methodMap, ok := methods.(map[string]interface{}); ok {
@@ -382,6 +483,10 @@ func contains(slice []string, item string) bool {
 
 func getMigrationGuide(change Change) string {
 	switch {
+	case change.Field != nil && change.Field.Type == ChangeRemoved:
+		return "Stop reading the removed field"
+	case change.Field != nil:
+		return fmt.Sprintf("Update client models for the new %s of the field", change.Field.Keyword)
 	case change.Description == "Request body removed":
 		return "Remove request body from client calls"
 	case change.Description == "Required request body added":
@@ -0,0 +1,252 @@
+package versioning
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCompare_Webhooks(t *testing.T) {
+	oldSpec := map[string]interface{}{
+		"info": map[string]interface{}{"version": "1.0.0"},
+		"webhooks": map[string]interface{}{
+			"userCreated": map[string]interface{}{
+				"post": map[string]interface{}{"responses": map[string]interface{}{"200": map[string]interface{}{}}},
+			},
+			"userDeleted": map[string]interface{}{
+				"post": map[string]interface{}{"responses": map[string]interface{}{"200": map[string]interface{}{}}},
+			},
+		},
+	}
+	newSpec := map[string]interface{}{
+		"info": map[string]interface{}{"version": "2.0.0"},
+		"webhooks": map[string]interface{}{
+			"userCreated": map[string]interface{}{
+				"post": map[string]interface{}{"responses": map[string]interface{}{"200": map[string]interface{}{}}},
+			},
+			"orderPlaced": map[string]interface{}{
+				"post": map[string]interface{}{"responses": map[string]interface{}{"200": map[string]interface{}{}}},
+			},
+		},
+	}
+
+	diff, err := NewDiffer().Compare(oldSpec, newSpec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff.Summary.AddedWebhooks != 1 {
+		t.Errorf("expected 1 added webhook, got %d", diff.Summary.AddedWebhooks)
+	}
+	if diff.Summary.RemovedWebhooks != 1 {
+		t.Errorf("expected 1 removed webhook, got %d", diff.Summary.RemovedWebhooks)
+	}
+	if !diff.HasBreakingChanges() {
+		t.Error("expected removed webhook to be breaking")
+	}
+	if len(diff.Breaking) != 1 || diff.Breaking[0].Path != "userDeleted" {
+		t.Errorf("expected userDeleted breaking change, got %+v", diff.Breaking)
+	}
+}
+
+func TestCompare_ResolvesLocalRefs(t *testing.T) {
+	specWith := func(required ...interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"paths": map[string]interface{}{
+				"/users": map[string]interface{}{
+					"post": map[string]interface{}{
+						"requestBody": map[string]interface{}{"$ref": "#/components/requestBodies/CreateUser"},
+						"parameters":  []interface{}{map[string]interface{}{"$ref": "#/components/parameters/Missing"}},
+					},
+				},
+			},
+			"components": map[string]interface{}{
+				"requestBodies": map[string]interface{}{
+					"CreateUser": map[string]interface{}{
+						"content": map[string]interface{}{
+							"application/json": map[string]interface{}{
+								"schema": map[string]interface{}{"type": "object", "required": required},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	diff, err := NewDiffer().Compare(specWith("name"), specWith("name", "email"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !diff.HasBreakingChanges() {
+		t.Error("expected new required field behind $ref to be detected")
+	}
+	if len(diff.Warnings) != 2 {
+		t.Errorf("expected unresolved ref warnings for both specs, got %v", diff.Warnings)
+	}
+}
+
+func TestCheckVersionBump(t *testing.T) {
+	specWith := func(version, summary string) map[string]interface{} {
+		return map[string]interface{}{
+			"info": map[string]interface{}{"version": version},
+			"paths": map[string]interface{}{
+				"/users": map[string]interface{}{
+					"get": map[string]interface{}{"summary": summary},
+				},
+			},
+		}
+	}
+
+	differ := NewDiffer()
+	if err := differ.CheckVersionBump(specWith("1.0.0", "List"), specWith("1.0.0", "List")); err != nil {
+		t.Errorf("expected identical specs to pass, got %v", err)
+	}
+	if err := differ.CheckVersionBump(specWith("1.0.0", "List"), specWith("1.1.0", "List users")); err != nil {
+		t.Errorf("expected bumped version to pass, got %v", err)
+	}
+	err := differ.CheckVersionBump(specWith("1.0.0", "List"), specWith("1.0.0", "List users"))
+	if !errors.Is(err, ErrVersionNotBumped) {
+		t.Errorf("expected ErrVersionNotBumped for a summary-only change, got %v", err)
+	}
+}
+
+func TestDeprecationReport(t *testing.T) {
+	doc := map[string]interface{}{
+		"paths": map[string]interface{}{
+			"/users": map[string]interface{}{
+				"get": map[string]interface{}{
+					"deprecated": true,
+					"x-sunset":   "2027-01-01",
+					"parameters": []interface{}{
+						map[string]interface{}{"name": "page", "in": "query", "deprecated": true},
+						map[string]interface{}{"name": "limit", "in": "query"},
+					},
+					"responses": map[string]interface{}{"200": map[string]interface{}{
+						"content": map[string]interface{}{"application/json": map[string]interface{}{
+							"schema": map[string]interface{}{"$ref": "#/components/schemas/User"},
+						}},
+					}},
+				},
+			},
+		},
+		"components": map[string]interface{}{
+			"schemas": map[string]interface{}{
+				"User": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"name":     map[string]interface{}{"type": "string", "deprecated": true, "x-deprecation-reason": "Use fullName"},
+						"fullName": map[string]interface{}{"type": "string"},
+					},
+				},
+			},
+		},
+	}
+
+	report := DeprecationReport(doc)
+	if len(report) != 3 {
+		t.Fatalf("expected 3 deprecations, got %+v", report)
+	}
+	if report[0].Kind != DeprecatedField || report[0].Path != "#/components/schemas/User" || report[0].Name != "name" || report[0].Reason != "Use fullName" {
+		t.Errorf("unexpected field deprecation %+v", report[0])
+	}
+	if report[1].Kind != DeprecatedOperation || report[1].Sunset != "2027-01-01" {
+		t.Errorf("unexpected operation deprecation %+v", report[1])
+	}
+	if report[2].Kind != DeprecatedParameter || report[2].Name != "page" {
+		t.Errorf("unexpected parameter deprecation %+v", report[2])
+	}
+}
+
+func TestCompare_NewDeprecations(t *testing.T) {
+	op := func(deprecated bool) map[string]interface{} {
+		return map[string]interface{}{
+			"deprecated": deprecated,
+			"x-sunset":   "2027-01-01",
+			"responses": map[string]interface{}{"200": map[string]interface{}{
+				"content": map[string]interface{}{"application/json": map[string]interface{}{
+					"schema": map[string]interface{}{"$ref": "#/components/schemas/User"},
+				}},
+			}},
+		}
+	}
+	doc := func(deprecated bool) map[string]interface{} {
+		return map[string]interface{}{
+			"paths": map[string]interface{}{"/users": map[string]interface{}{"get": op(deprecated)}},
+			"components": map[string]interface{}{"schemas": map[string]interface{}{
+				"User": map[string]interface{}{
+					"type":       "object",
+					"properties": map[string]interface{}{"name": map[string]interface{}{"type": "string", "deprecated": deprecated}},
+				},
+			}},
+		}
+	}
+
+	diff, err := NewDiffer().Compare(doc(false), doc(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff.HasBreakingChanges() {
+		t.Errorf("expected deprecations to be non-breaking, got %+v", diff.Breaking)
+	}
+	if len(diff.Changes) != 2 {
+		t.Fatalf("expected 2 changes, got %+v", diff.Changes)
+	}
+	if diff.Changes[0].Description != "Operation deprecated (sunset 2027-01-01)" {
+		t.Errorf("unexpected change %q", diff.Changes[0].Description)
+	}
+	if diff.Changes[1].Description != "Field 'responses.200.name' deprecated" {
+		t.Errorf("unexpected change %q", diff.Changes[1].Description)
+	}
+}
+
+func TestSchemaDiff(t *testing.T) {
+	oldSchema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"id":     map[string]interface{}{"type": "integer"},
+			"status": map[string]interface{}{"type": "string", "enum": []interface{}{"active", "banned"}},
+			"tags":   map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+			"legacy": map[string]interface{}{"type": "string"},
+		},
+	}
+	newSchema := map[string]interface{}{
+		"type": "object",
+		"allOf": []interface{}{map[string]interface{}{
+			"properties": map[string]interface{}{"id": map[string]interface{}{"type": "string", "format": "uuid"}},
+		}},
+		"properties": map[string]interface{}{
+			"status": map[string]interface{}{"type": "string", "enum": []interface{}{"active", "banned", "pending"}},
+			"tags":   map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string", "maxLength": 20.0}},
+			"email":  map[string]interface{}{"type": "string"},
+		},
+	}
+
+	changes := SchemaDiff(oldSchema, newSchema)
+	got := make([]string, len(changes))
+	for i, fc := range changes {
+		got[i] = fc.describe("Response 200")
+	}
+	want := []string{
+		"Response 200 field /email added",
+		"Response 200 field /id type changed from integer to string",
+		"Response 200 field /id format changed from none to uuid",
+		"Response 200 field /legacy removed",
+		"Response 200 field /status enum changed from [active banned] to [active banned pending]",
+		"Response 200 field /tags/* maxLength changed from none to 20",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected field changes:\n%s", strings.Join(got, "\n"))
+	}
+
+	if !changes[3].isBreaking(false) || changes[3].isBreaking(true) {
+		t.Error("expected a removed field to break responses only")
+	}
+	if !changes[4].isBreaking(false) || changes[4].isBreaking(true) {
+		t.Error("expected a new enum value to break responses only")
+	}
+	if !changes[5].isBreaking(true) || changes[5].isBreaking(false) {
+		t.Error("expected a new maxLength to break requests only")
+	}
+}
@@ -0,0 +1,145 @@
+package versioning
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Diff output formats supported by FormatDiff
+const (
+	FormatText     = "text"
+	FormatJSON     = "json"
+	FormatMarkdown = "markdown"
+	FormatJUnit    = "junit"
+)
+
+// FormatDiff renders a diff as plain text, JSON, markdown (changelog plus
+// migration guide) or JUnit XML, where every changed endpoint is a testcase
+// and its breaking changes are failures
+func FormatDiff(diff *Diff, format string) (string, error) {
+	switch strings.ToLower(format) {
+	case FormatText, "":
+		return formatText(diff), nil
+	case FormatJSON:
+		data, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	case FormatMarkdown:
+		markdown := GenerateChangelog(diff)
+		if diff.HasBreakingChanges() {
+			markdown += "\n" + NewMigrationGenerator().Generate(diff).ToMarkdown()
+		}
+		return markdown, nil
+	case FormatJUnit:
+		return formatJUnit(diff)
+	default:
+		return "", fmt.Errorf("unknown diff format %q (want text, json, markdown or junit)", format)
+	}
+}
+
+func formatText(diff *Diff) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("API diff %s -> %s\n", diff.OldVersion, diff.NewVersion))
+	sb.WriteString(fmt.Sprintf("Added: %d, removed: %d, modified: %d, breaking: %d\n",
+		diff.Summary.AddedEndpoints, diff.Summary.RemovedEndpoints,
+		diff.Summary.ModifiedEndpoints, diff.Summary.BreakingChanges))
+
+	for _, change := range diff.Changes {
+		icon := "+"
+		switch change.Type {
+		case ChangeRemoved:
+			icon = "-"
+		case ChangeModified:
+			icon = "~"
+		}
+		breaking := ""
+		if change.IsBreaking {
+			breaking = " [BREAKING]"
+		}
+		sb.WriteString(fmt.Sprintf("%s %s %s: %s%s\n", icon, change.Method, change.Path, change.Description, breaking))
+	}
+
+	for _, bc := range diff.Breaking {
+		sb.WriteString(fmt.Sprintf("\n[%s %s]\n  Reason: %s\n  Migration: %s\n", bc.Method, bc.Path, bc.Reason, bc.Migration))
+	}
+
+	return sb.String()
+}
+
+type junitSuite struct {
+	XMLName   xml.Name    `xml:"testsuite"`
+	Name      string      `xml:"name,attr"`
+	Tests     int         `xml:"tests,attr"`
+	Failures  int         `xml:"failures,attr"`
+	TestCases []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func formatJUnit(diff *Diff) (string, error) {
+	breaking := make(map[string][]BreakingChange)
+	names := []string{}
+	seen := make(map[string]bool)
+	add := func(method, path string) string {
+		name := strings.ToUpper(method) + " " + path
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+		return name
+	}
+
+	for _, change := range diff.Changes {
+		add(change.Method, change.Path)
+	}
+	for _, bc := range diff.Breaking {
+		name := add(bc.Method, bc.Path)
+		breaking[name] = append(breaking[name], bc)
+	}
+	sort.Strings(names)
+
+	suite := junitSuite{
+		Name:  fmt.Sprintf("API compatibility %s -> %s", diff.OldVersion, diff.NewVersion),
+		Tests: len(names),
+	}
+	for _, name := range names {
+		tc := junitCase{ClassName: "api-compatibility", Name: name}
+		if changes := breaking[name]; len(changes) > 0 {
+			reasons := make([]string, len(changes))
+			details := make([]string, len(changes))
+			for i, bc := range changes {
+				reasons[i] = bc.Reason
+				details[i] = bc.Reason + ": " + bc.Migration
+			}
+			tc.Failure = &junitFailure{
+				Message: strings.Join(reasons, "; "),
+				Type:    "BreakingChange",
+				Text:    strings.Join(details, "\n"),
+			}
+			suite.Failures++
+		}
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+
+	data, err := xml.MarshalIndent(suite, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return xml.Header + string(data), nil
+}
@@ -0,0 +1,48 @@
+package versioning
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestFormatDiff_JUnit(t *testing.T) {
+	diff := &Diff{
+		OldVersion: "1.0.0",
+		NewVersion: "2.0.0",
+		Changes: []Change{
+			{Type: ChangeAdded, Path: "/products", Method: "get", Description: "New endpoint: get /products"},
+			{Type: ChangeRemoved, Path: "/users/{id}", Method: "delete", Description: "Removed endpoint", IsBreaking: true},
+		},
+		Breaking: []BreakingChange{
+			{Path: "/users/{id}", Method: "delete", Reason: "Endpoint removed", Migration: "Stop calling it"},
+		},
+		Summary: Summary{BreakingChanges: 1},
+	}
+
+	out, err := FormatDiff(diff, FormatJUnit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var suite junitSuite
+	if err := xml.Unmarshal([]byte(out), &suite); err != nil {
+		t.Fatalf("invalid JUnit XML: %v\n%s", err, out)
+	}
+	if suite.Tests != 2 || suite.Failures != 1 {
+		t.Fatalf("expected 2 tests with 1 failure, got %d/%d", suite.Tests, suite.Failures)
+	}
+	if tc := suite.TestCases[0]; tc.Name != "DELETE /users/{id}" || tc.Failure == nil || tc.Failure.Message != "Endpoint removed" {
+		t.Errorf("expected failing DELETE testcase, got %+v", tc)
+	}
+	if suite.TestCases[1].Failure != nil {
+		t.Errorf("expected added endpoint to pass, got %+v", suite.TestCases[1])
+	}
+
+	if _, err := FormatDiff(diff, "yaml"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+	if md, _ := FormatDiff(diff, FormatMarkdown); !strings.Contains(md, "Migration Guide") {
+		t.Errorf("expected markdown to include the migration guide, got %s", md)
+	}
+}
@@ -0,0 +1,45 @@
+package versioning
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrVersionNotBumped is returned by CheckVersionBump when the spec content
+// changed but info.version did not
+var ErrVersionNotBumped = errors.New("spec changed without a version bump")
+
+// CheckVersionBump compares the full content of two specs, not only their
+// endpoints, and returns an error wrapping ErrVersionNotBumped when they
+// differ while declaring the same info.version. Use it in CI to catch API
+// changes shipped under an old version number.
+func (d *Differ) CheckVersionBump(oldSpec, newSpec map[string]interface{}) error {
+	if getVersion(oldSpec) != getVersion(newSpec) {
+		return nil
+	}
+
+	changed, err := contentChanged(oldSpec, newSpec)
+	if err != nil {
+		return err
+	}
+	if !changed {
+		return nil
+	}
+	return fmt.Errorf("%w (info.version is still %s)", ErrVersionNotBumped, getVersion(newSpec))
+}
+
+// contentChanged reports whether two specs differ. Both are compared in their
+// JSON form, which sorts map keys and unifies slice types.
+func contentChanged(oldSpec, newSpec map[string]interface{}) (bool, error) {
+	oldJSON, err := json.Marshal(oldSpec)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode old spec: %w", err)
+	}
+	newJSON, err := json.Marshal(newSpec)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode new spec: %w", err)
+	}
+	return !bytes.Equal(oldJSON, newJSON), nil
+}
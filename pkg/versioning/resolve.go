@@ -0,0 +1,59 @@
+package versioning
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/andrianprasetya/open-swag-go/pkg/spec"
+)
+
+// ResolveRefs returns a copy of doc with local "#/..." refs replaced by their
+// targets so the differ compares the effective shape of each operation.
+// Refs that cannot be resolved are left in place and reported in the returned
+// notes; recursive refs are left in place at the point where they recur.
+func ResolveRefs(doc map[string]interface{}) (map[string]interface{}, []string) {
+	r := &refResolver{root: doc}
+	resolved, _ := r.resolve(doc, nil).(map[string]interface{})
+	return resolved, r.notes
+}
+
+type refResolver struct {
+	root  map[string]interface{}
+	notes []string
+}
+
+func (r *refResolver) resolve(node interface{}, stack []string) interface{} {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		if ref, ok := v["$ref"].(string); ok && strings.HasPrefix(ref, "#") {
+			return r.resolveRef(v, ref, stack)
+		}
+		out := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			out[key] = r.resolve(child, stack)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, child := range v {
+			out[i] = r.resolve(child, stack)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+func (r *refResolver) resolveRef(node map[string]interface{}, ref string, stack []string) interface{} {
+	if contains(stack, ref) {
+		return node
+	}
+
+	target, err := spec.LookupPointer(r.root, strings.TrimPrefix(ref, "#"))
+	if err != nil {
+		r.notes = append(r.notes, fmt.Sprintf("unresolved $ref %s: %v", ref, err))
+		return node
+	}
+
+	return r.resolve(target, append(stack, ref))
+}
@@ -0,0 +1,229 @@
+package versioning
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// FieldChange is a single property-level difference between two schemas.
+// Pointer addresses the field in the data the schema describes, such as
+// /address/street; array elements appear as the "*" segment (/tags/*) and
+// the schema itself as the empty pointer. Modifications name the changed
+// Keyword (type, format, enum, minimum, ...) with its Old and New values.
+type FieldChange struct {
+	Pointer string      `json:"pointer"`
+	Type    ChangeType  `json:"type"`
+	Keyword string      `json:"keyword,omitempty"`
+	Old     interface{} `json:"old,omitempty"`
+	New     interface{} `json:"new,omitempty"`
+}
+
+// comparedKeywords are the schema keywords whose changes SchemaDiff reports
+var comparedKeywords = []string{
+	"type", "format", "enum", "pattern",
+	"minimum", "maximum", "minLength", "maxLength", "minItems", "maxItems",
+}
+
+// SchemaDiff walks two (dereferenced) schemas and reports added and removed
+// properties and changed keywords, in property name order. Properties
+// contributed by allOf members are compared as if they were declared inline.
+func SchemaDiff(oldSchema, newSchema map[string]interface{}) []FieldChange {
+	changes := []FieldChange{}
+	diffSchemas(oldSchema, newSchema, "", &changes, 0)
+	return changes
+}
+
+func diffSchemas(oldSchema, newSchema map[string]interface{}, pointer string, changes *[]FieldChange, depth int) {
+	// Recursive schemas keep their $ref after resolution, but guard anyway
+	if depth > 32 {
+		return
+	}
+
+	for _, keyword := range comparedKeywords {
+		oldValue, newValue := oldSchema[keyword], newSchema[keyword]
+		if !reflect.DeepEqual(oldValue, newValue) {
+			*changes = append(*changes, FieldChange{
+				Pointer: pointer,
+				Type:    ChangeModified,
+				Keyword: keyword,
+				Old:     oldValue,
+				New:     newValue,
+			})
+		}
+	}
+
+	oldProps := effectiveProperties(oldSchema)
+	newProps := effectiveProperties(newSchema)
+	for _, name := range sortedKeys(mergeKeys(oldProps, newProps)) {
+		fieldPointer := pointer + "/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+		oldProp, inOld := oldProps[name]
+		newProp, inNew := newProps[name]
+		switch {
+		case !inOld:
+			*changes = append(*changes, FieldChange{Pointer: fieldPointer, Type: ChangeAdded})
+		case !inNew:
+			*changes = append(*changes, FieldChange{Pointer: fieldPointer, Type: ChangeRemoved})
+		default:
+			diffSchemas(oldProp, newProp, fieldPointer, changes, depth+1)
+		}
+	}
+
+	oldItems, _ := oldSchema["items"].(map[string]interface{})
+	newItems, _ := newSchema["items"].(map[string]interface{})
+	if oldItems != nil && newItems != nil {
+		diffSchemas(oldItems, newItems, pointer+"/*", changes, depth+1)
+	}
+}
+
+// effectiveProperties merges a schema's properties with those of its allOf members
+func effectiveProperties(schema map[string]interface{}) map[string]map[string]interface{} {
+	props := make(map[string]map[string]interface{})
+	if own, ok := schema["properties"].(map[string]interface{}); ok {
+		for name, p := range own {
+			if prop, ok := p.(map[string]interface{}); ok {
+				props[name] = prop
+			}
+		}
+	}
+	if members, ok := schema["allOf"].([]interface{}); ok {
+		for _, m := range members {
+			if member, ok := m.(map[string]interface{}); ok {
+				for name, prop := range effectiveProperties(member) {
+					props[name] = prop
+				}
+			}
+		}
+	}
+	return props
+}
+
+func mergeKeys(a, b map[string]map[string]interface{}) map[string]bool {
+	keys := make(map[string]bool, len(a)+len(b))
+	for key := range a {
+		keys[key] = true
+	}
+	for key := range b {
+		keys[key] = true
+	}
+	return keys
+}
+
+// describe renders the change for a body, e.g. "Response 200 field /id removed"
+func (fc FieldChange) describe(body string) string {
+	subject := body
+	if fc.Pointer != "" {
+		subject += " field " + fc.Pointer
+	}
+
+	switch fc.Type {
+	case ChangeAdded:
+		return subject + " added"
+	case ChangeRemoved:
+		return subject + " removed"
+	}
+	return fmt.Sprintf("%s %s changed from %s to %s", subject, fc.Keyword, formatKeyword(fc.Old), formatKeyword(fc.New))
+}
+
+func formatKeyword(value interface{}) string {
+	if value == nil {
+		return "none"
+	}
+	return fmt.Sprint(value)
+}
+
+// isBreaking classifies a field change: removed response fields and changed
+// types or formats break clients everywhere, while constraints only break
+// them when a request accepts less than before or a response may carry
+// values the client has not seen (new enum values)
+func (fc FieldChange) isBreaking(inRequest bool) bool {
+	switch fc.Type {
+	case ChangeAdded:
+		return false
+	case ChangeRemoved:
+		return !inRequest
+	}
+
+	switch fc.Keyword {
+	case "type", "format":
+		return true
+	case "enum":
+		if inRequest {
+			return !enumSubset(fc.Old, fc.New)
+		}
+		return !enumSubset(fc.New, fc.Old)
+	case "pattern":
+		return inRequest && fc.New != nil
+	case "minimum", "minLength", "minItems":
+		return inRequest && (fc.Old == nil || numberLess(fc.Old, fc.New))
+	case "maximum", "maxLength", "maxItems":
+		return inRequest && (fc.Old == nil || numberLess(fc.New, fc.Old))
+	}
+	return false
+}
+
+// enumSubset reports whether every value of enum a is allowed by enum b; a
+// missing enum allows everything
+func enumSubset(a, b interface{}) bool {
+	bValues, ok := b.([]interface{})
+	if !ok {
+		return true
+	}
+	aValues, ok := a.([]interface{})
+	if !ok {
+		return false
+	}
+	for _, value := range aValues {
+		found := false
+		for _, allowed := range bValues {
+			if reflect.DeepEqual(value, allowed) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func numberLess(a, b interface{}) bool {
+	x, okA := a.(float64)
+	y, okB := b.(float64)
+	return okA && okB && x < y
+}
+
+// fieldChanges diffs the request body and the responses present in both
+// operations, one Change per field change
+func fieldChanges(path, method string, oldOp, newOp map[string]interface{}) []Change {
+	changes := []Change{}
+	add := func(body string, inRequest bool, oldBody, newBody interface{}) {
+		oldSchema, newSchema := firstSchema(oldBody), firstSchema(newBody)
+		if oldSchema == nil || newSchema == nil {
+			return
+		}
+		for _, fc := range SchemaDiff(oldSchema, newSchema) {
+			field := fc
+			changes = append(changes, Change{
+				Type:        ChangeModified,
+				Path:        path,
+				Method:      method,
+				Description: fc.describe(body),
+				IsBreaking:  fc.isBreaking(inRequest),
+				Field:       &field,
+			})
+		}
+	}
+
+	add("Request body", true, oldOp["requestBody"], newOp["requestBody"])
+
+	oldResponses, _ := oldOp["responses"].(map[string]interface{})
+	newResponses, _ := newOp["responses"].(map[string]interface{})
+	for _, code := range sortedKeys(oldResponses) {
+		if newResp, ok := newResponses[code]; ok {
+			add("Response "+code, false, oldResponses[code], newResp)
+		}
+	}
+	return changes
+}
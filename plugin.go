@@ -0,0 +1,35 @@
+package openswag
+
+import (
+	"fmt"
+
+	"github.com/andrianprasetya/open-swag-go/pkg/spec"
+)
+
+// Use registers a mutator that post-processes the spec, e.g. to inject
+// extensions, rewrite descriptions or add governance metadata. Mutators run
+// in registration order at the end of every build (public and internal),
+// after the paths, webhooks, component schemas, security schemes and
+// PatchSchema patches are in place, so each sees the fully built spec and
+// the changes of the mutators before it.
+//
+// A mutator error aborts the build: SpecJSON, the spec handler, Validate and
+// BuildSpecE report the error until the next change to the Docs, while
+// BuildSpec returns the spec as far as the mutators got. Mutators run while
+// the Docs is locked and must not call its methods.
+func (d *Docs) Use(mutator func(*spec.OpenAPI) error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.mutators = append(d.mutators, mutator)
+	d.invalidate()
+}
+
+// applyMutators runs the registered mutators, stopping at the first error
+func (d *Docs) applyMutators(openapi *spec.OpenAPI) error {
+	for i, mutate := range d.mutators {
+		if err := mutate(openapi); err != nil {
+			return fmt.Errorf("spec mutator %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
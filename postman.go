@@ -0,0 +1,205 @@
+package openswag
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// postmanSchema identifies the Postman Collection format written by ToPostmanCollection
+const postmanSchema = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+type postmanCollection struct {
+	Info     postmanInfo       `json:"info"`
+	Item     []postmanItem     `json:"item"`
+	Auth     *postmanAuth      `json:"auth,omitempty"`
+	Variable []postmanKeyValue `json:"variable,omitempty"`
+}
+
+type postmanInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Schema      string `json:"schema"`
+}
+
+// postmanItem is either a folder (Item set) or a request
+type postmanItem struct {
+	Name    string          `json:"name"`
+	Item    []postmanItem   `json:"item,omitempty"`
+	Request *postmanRequest `json:"request,omitempty"`
+}
+
+type postmanRequest struct {
+	Method      string            `json:"method"`
+	Description string            `json:"description,omitempty"`
+	Header      []postmanKeyValue `json:"header"`
+	URL         postmanURL        `json:"url"`
+	Body        *postmanBody      `json:"body,omitempty"`
+	Auth        *postmanAuth      `json:"auth,omitempty"`
+}
+
+type postmanURL struct {
+	Raw      string            `json:"raw"`
+	Host     []string          `json:"host"`
+	Path     []string          `json:"path"`
+	Query    []postmanKeyValue `json:"query,omitempty"`
+	Variable []postmanKeyValue `json:"variable,omitempty"`
+}
+
+type postmanBody struct {
+	Mode       string                 `json:"mode"`
+	Raw        string                 `json:"raw,omitempty"`
+	URLEncoded []postmanKeyValue      `json:"urlencoded,omitempty"`
+	FormData   []postmanKeyValue      `json:"formdata,omitempty"`
+	Options    map[string]interface{} `json:"options,omitempty"`
+}
+
+type postmanAuth struct {
+	Type   string            `json:"type"`
+	Bearer []postmanKeyValue `json:"bearer,omitempty"`
+	Basic  []postmanKeyValue `json:"basic,omitempty"`
+	APIKey []postmanKeyValue `json:"apikey,omitempty"`
+}
+
+type postmanKeyValue struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Type        string `json:"type,omitempty"`
+	Description string `json:"description,omitempty"`
+	Disabled    bool   `json:"disabled,omitempty"`
+}
+
+// ToPostmanCollection converts the public spec into a Postman Collection
+// v2.1 with one folder per operation's first tag. URLs start with the
+// {{baseUrl}} variable, set to the first server. Request bodies come from
+// documented examples or are generated from the body type. Auth is mapped
+// from the security schemes, with credentials left as {{variables}}.
+func (d *Docs) ToPostmanCollection() ([]byte, error) {
+	openapi, requests, err := d.exportRequests()
+	if err != nil {
+		return nil, err
+	}
+
+	collection := postmanCollection{
+		Info: postmanInfo{
+			Name:        d.config.Info.Title,
+			Description: d.config.Info.Description,
+			Schema:      postmanSchema,
+		},
+		Item:     []postmanItem{},
+		Variable: []postmanKeyValue{{Key: "baseUrl", Value: d.exportBaseURL()}},
+		Auth:     postmanAuthFor(exportAuth(openapi, openapi.Security)),
+	}
+
+	// Folders keep the order in which their tag first appears
+	folders := make(map[string]int)
+	for _, req := range requests {
+		item := postmanItem{Name: req.Name, Request: postmanRequestFor(req)}
+		if req.Folder == "" {
+			collection.Item = append(collection.Item, item)
+			continue
+		}
+		i, ok := folders[req.Folder]
+		if !ok {
+			i = len(collection.Item)
+			folders[req.Folder] = i
+			collection.Item = append(collection.Item, postmanItem{Name: req.Folder})
+		}
+		collection.Item[i].Item = append(collection.Item[i].Item, item)
+	}
+
+	return json.MarshalIndent(collection, "", "  ")
+}
+
+// postmanRequestFor renders an exported request; Postman writes path
+// variables as :name, which the shared path segments already use
+func postmanRequestFor(req exportedRequest) *postmanRequest {
+	request := &postmanRequest{
+		Method:      req.Method,
+		Description: req.Description,
+		Header:      []postmanKeyValue{},
+	}
+
+	host := "{{baseUrl}}"
+	if req.Server != "" {
+		host = req.Server
+	}
+	request.URL.Host = []string{host}
+	request.URL.Path = req.Path
+
+	for _, p := range req.PathParams {
+		request.URL.Variable = append(request.URL.Variable, postmanKeyValue{Key: p.Name, Value: p.Value, Description: p.Description})
+	}
+	var query []string
+	for _, p := range req.Query {
+		request.URL.Query = append(request.URL.Query, postmanKeyValue{Key: p.Name, Value: p.Value, Description: p.Description, Disabled: !p.Required})
+		if p.Required {
+			query = append(query, p.Name+"="+p.Value)
+		}
+	}
+	for _, p := range req.Headers {
+		request.Header = append(request.Header, postmanKeyValue{Key: p.Name, Value: p.Value, Description: p.Description, Disabled: !p.Required})
+	}
+
+	request.URL.Raw = host + "/" + strings.Join(request.URL.Path, "/")
+	if len(query) > 0 {
+		request.URL.Raw += "?" + strings.Join(query, "&")
+	}
+
+	if req.ContentType != "" {
+		request.Header = append(request.Header, postmanKeyValue{Key: "Content-Type", Value: req.ContentType})
+		request.Body = postmanBodyFor(req)
+	}
+
+	if req.Auth != nil {
+		request.Auth = postmanAuthFor(req.Auth)
+	}
+	return request
+}
+
+// postmanBodyFor picks the body mode matching the content type
+func postmanBodyFor(req exportedRequest) *postmanBody {
+	switch req.ContentType {
+	case ContentTypeURLEncoded, ContentTypeMultipart:
+		var fields []postmanKeyValue
+		for _, f := range exportFields(req.Body) {
+			fields = append(fields, postmanKeyValue{Key: f.Name, Value: f.Value, Type: "text"})
+		}
+		if req.ContentType == ContentTypeMultipart {
+			return &postmanBody{Mode: "formdata", FormData: fields}
+		}
+		return &postmanBody{Mode: "urlencoded", URLEncoded: fields}
+	}
+
+	return &postmanBody{
+		Mode:    "raw",
+		Raw:     exportJSON(req.Body),
+		Options: map[string]interface{}{"raw": map[string]string{"language": "json"}},
+	}
+}
+
+// postmanAuthFor renders exported auth, with credentials as {{variables}}
+func postmanAuthFor(auth *exportedAuth) *postmanAuth {
+	if auth == nil {
+		return nil
+	}
+	switch auth.Type {
+	case "bearer":
+		return &postmanAuth{Type: "bearer", Bearer: []postmanKeyValue{
+			{Key: "token", Value: "{{bearerToken}}", Type: "string"},
+		}}
+	case "basic":
+		return &postmanAuth{Type: "basic", Basic: []postmanKeyValue{
+			{Key: "username", Value: "{{username}}", Type: "string"},
+			{Key: "password", Value: "{{password}}", Type: "string"},
+		}}
+	case "apikey":
+		return &postmanAuth{Type: "apikey", APIKey: []postmanKeyValue{
+			{Key: "key", Value: auth.Name, Type: "string"},
+			{Key: "value", Value: "{{apiKey}}", Type: "string"},
+			{Key: "in", Value: auth.In, Type: "string"},
+		}}
+	case "oauth2":
+		return &postmanAuth{Type: "oauth2"}
+	}
+	return &postmanAuth{Type: "noauth"}
+}
@@ -0,0 +1,77 @@
+package openswag
+
+import (
+	"sort"
+
+	"github.com/andrianprasetya/open-swag-go/pkg/spec"
+)
+
+// PreferHeader is the RFC 7240 request header documented by PreferParam
+const PreferHeader = "Prefer"
+
+// PreferParam creates the optional Prefer header parameter limited to the
+// given preferences, "return=minimal" and "return=representation" by
+// default, so the Try-It console offers them as a choice
+func PreferParam(preferences ...string) Parameter {
+	if len(preferences) == 0 {
+		preferences = []string{"return=minimal", "return=representation"}
+	}
+	values := make([]any, len(preferences))
+	for i, preference := range preferences {
+		values[i] = preference
+	}
+	return HeaderParam(PreferHeader, "Preferences for how the server should respond (RFC 7240)").
+		WithSchema(&spec.Schema{Type: "string", Enum: values})
+}
+
+// preferVariants converts a response's Prefer variants into the
+// x-prefer-variants entry of its status code
+func (d *Docs) preferVariants(resp Response) map[string]interface{} {
+	variants := make(map[string]interface{}, len(resp.Prefer))
+	for preference, body := range resp.Prefer {
+		if body == nil {
+			variants[preference] = map[string]interface{}{"description": "No body"}
+			continue
+		}
+		variants[preference] = map[string]interface{}{"schema": d.schemaFor(body)}
+	}
+	return variants
+}
+
+// applyPrefer documents the collected response variants as the
+// x-prefer-variants extension, adds the Prefer header parameter and the
+// Preference-Applied response header
+func applyPrefer(op *spec.Operation, variants map[string]interface{}) {
+	if len(variants) == 0 {
+		return
+	}
+	op.WithExtension("x-prefer-variants", variants)
+
+	if !hasParam(op.Parameters, PreferHeader) {
+		seen := make(map[string]bool)
+		var preferences []string
+		for _, byPreference := range variants {
+			for preference := range byPreference.(map[string]interface{}) {
+				if !seen[preference] {
+					seen[preference] = true
+					preferences = append(preferences, preference)
+				}
+			}
+		}
+		sort.Strings(preferences)
+
+		param := PreferParam(preferences...)
+		op.AddParameter(spec.HeaderParam(param.Name).
+			WithDescription(param.Description).
+			WithSchema(param.Schema))
+	}
+
+	for code := range variants {
+		if resp := op.Responses[code]; resp != nil {
+			resp.AddHeader("Preference-Applied", &spec.Header{
+				Description: "The preferences of the Prefer header that were honored",
+				Schema:      spec.NewSchema("string"),
+			})
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package openswag
+
+import "github.com/andrianprasetya/open-swag-go/pkg/spec"
+
+// RateLimit documents how many requests an endpoint accepts per window
+type RateLimit struct {
+	Requests int    `json:"requests"`
+	Window   string `json:"window"` // e.g. "1m", "1h"
+}
+
+// applyRateLimit adds the x-ratelimit extension and the X-RateLimit-*
+// headers to every response of the operation
+func applyRateLimit(ep Endpoint, op *spec.Operation) {
+	if ep.RateLimit == nil {
+		return
+	}
+
+	op.WithExtension("x-ratelimit", *ep.RateLimit)
+
+	for _, resp := range op.Responses {
+		resp.AddHeader("X-RateLimit-Limit", &spec.Header{
+			Description: "Requests allowed per " + ep.RateLimit.Window,
+			Schema:      spec.NewSchema("integer"),
+			Example:     ep.RateLimit.Requests,
+		})
+		resp.AddHeader("X-RateLimit-Remaining", &spec.Header{
+			Description: "Requests left in the current window",
+			Schema:      spec.NewSchema("integer"),
+		})
+		resp.AddHeader("X-RateLimit-Reset", &spec.Header{
+			Description: "Seconds until the current window resets",
+			Schema:      spec.NewSchema("integer"),
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package openswag
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/andrianprasetya/open-swag-go/pkg/spec"
+)
+
+// FileDownloadResponse documents a binary file download such as application/pdf
+func FileDownloadResponse(description, contentType string) Response {
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return Response{
+		Description: description,
+		ContentType: contentType,
+	}
+}
+
+// StreamResponse documents a streamed body such as text/event-stream or a
+// chunked download, marked with the x-streaming extension
+func StreamResponse(description, contentType string) Response {
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return Response{
+		Description: description,
+		ContentType: contentType,
+		Streaming:   true,
+	}
+}
+
+// SSEResponse documents a text/event-stream response along with its event
+// catalog: the payload type of each event, keyed by event name (the SSE
+// "event:" field; use "message" for unnamed events). A nil payload documents
+// an event without data.
+func SSEResponse(description string, events map[string]interface{}) Response {
+	return Response{
+		Description: description,
+		ContentType: "text/event-stream",
+		Streaming:   true,
+		Events:      events,
+	}
+}
+
+// NoContentResponse documents a response without a body, typically 204
+func NoContentResponse(description string) Response {
+	if description == "" {
+		description = "No Content"
+	}
+	return Response{Description: description}
+}
+
+// binarySchema is the schema for a raw file response body
+func binarySchema() *spec.Schema {
+	return &spec.Schema{Type: "string", Format: "binary"}
+}
+
+// streamSchema is the schema for a streamed response body
+func streamSchema() *spec.Schema {
+	return (&spec.Schema{Type: "string"}).WithExtension("x-streaming", true)
+}
+
+// sseEvents adds the x-sse-events catalog to a stream schema, converting
+// each event's payload as a JSON body
+func (d *Docs) sseEvents(s *spec.Schema, events map[string]interface{}) *spec.Schema {
+	if len(events) == 0 {
+		return s
+	}
+	catalog := make(map[string]*spec.Schema, len(events))
+	for name, payload := range events {
+		if payload == nil {
+			catalog[name] = &spec.Schema{}
+			continue
+		}
+		catalog[name] = d.schemaForMedia(payload, ContentTypeJSON)
+	}
+	return s.WithExtension("x-sse-events", catalog)
+}
+
+// WithValidationErrors documents a 422 response with the given error schema
+// on every endpoint that accepts a request body, matching what a validation
+// middleware returns for rejected input. Endpoints documenting their own 422
+// keep it.
+func (d *Docs) WithValidationErrors(schema interface{}) *Docs {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.validationErrors = schema
+	d.invalidate()
+	return d
+}
+
+// applyValidationErrors adds the shared 422 response; callers must hold d.mu
+func (d *Docs) applyValidationErrors(ep Endpoint, op *spec.Operation) {
+	if d.validationErrors == nil || ep.RequestBody == nil {
+		return
+	}
+	if _, ok := ep.Responses[http.StatusUnprocessableEntity]; ok {
+		return
+	}
+	op.AddResponse(intToString(http.StatusUnprocessableEntity),
+		spec.NewResponse(d.responseDescription(http.StatusUnprocessableEntity, "Validation failed")).
+			WithContent(ContentTypeJSON, d.schemaFor(d.validationErrors)))
+}
+
+// SchemaWrapper wraps a response schema in an envelope, e.g. turning User
+// into {"data": User, "meta": Meta}
+type SchemaWrapper func(inner *spec.Schema) *spec.Schema
+
+// Envelope returns a SchemaWrapper that nests the response schema under
+// property, next to the given sibling properties
+func Envelope(property string, siblings map[string]*spec.Schema) SchemaWrapper {
+	return func(inner *spec.Schema) *spec.Schema {
+		envelope := &spec.Schema{
+			Type:       "object",
+			Properties: map[string]*spec.Schema{property: inner},
+			Required:   []string{property},
+		}
+		for name, sibling := range siblings {
+			envelope.Properties[name] = sibling
+		}
+		return envelope
+	}
+}
+
+// applyResponseWrappers wraps the JSON schemas of 2xx responses in
+// Config.ResponseWrapper and those of 4xx/5xx responses in Config.ErrorWrapper
+func (d *Docs) applyResponseWrappers(ep Endpoint, op *spec.Operation) {
+	if ep.RawResponses {
+		return
+	}
+
+	for code, resp := range op.Responses {
+		var wrap SchemaWrapper
+		switch {
+		case strings.HasPrefix(code, "2"):
+			wrap = d.config.ResponseWrapper
+		case strings.HasPrefix(code, "4"), strings.HasPrefix(code, "5"):
+			wrap = d.config.ErrorWrapper
+		}
+		if wrap == nil || resp == nil {
+			continue
+		}
+
+		for mediaType, media := range resp.Content {
+			isJSON := mediaType == ContentTypeJSON || strings.HasSuffix(mediaType, "+json")
+			if isJSON && media != nil && media.Schema != nil {
+				media.Schema = wrap(media.Schema)
+			}
+		}
+	}
+}
+
+// warnMissingSchemas logs 2xx responses that document no content. Apart
+// from 204 and 205, which never carry a body, these are usually endpoints
+// that forgot Response.Schema; bodiless 200s are legitimate, so this only warns.
+func (d *Docs) warnMissingSchemas(ep Endpoint, op *spec.Operation) {
+	for code, resp := range op.Responses {
+		if !strings.HasPrefix(code, "2") || code == "204" || code == "205" {
+			continue
+		}
+		if len(resp.Content) == 0 {
+			d.config.Logger.Warn("openswag: response has no schema", "method", ep.Method, "path", ep.Path, "status", code)
+		}
+	}
+}
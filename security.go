@@ -0,0 +1,26 @@
+package openswag
+
+// endpointSecurity returns the security schemes that apply to an endpoint.
+// Public endpoints get none. An explicit Endpoint.Security wins; otherwise
+// the defaults of every tag in Config.TagSecurity carried by the endpoint
+// are combined. Endpoints left without schemes inherit Config.Security.
+func (d *Docs) endpointSecurity(ep Endpoint) []string {
+	if ep.Public {
+		return nil
+	}
+	if len(ep.Security) > 0 {
+		return ep.Security
+	}
+
+	var schemes []string
+	seen := make(map[string]bool)
+	for _, tag := range ep.Tags {
+		for _, scheme := range d.config.TagSecurity[tag] {
+			if !seen[scheme] {
+				seen[scheme] = true
+				schemes = append(schemes, scheme)
+			}
+		}
+	}
+	return schemes
+}
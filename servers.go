@@ -0,0 +1,81 @@
+package openswag
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/andrianprasetya/open-swag-go/pkg/spec"
+)
+
+// requestServerURL derives the API's external URL from the incoming request.
+// The X-Forwarded-Proto and X-Forwarded-Host headers set by proxies are only
+// honored with Config.TrustForwardedHeaders, since clients can send them too.
+// It returns "" when the host isn't a plain host[:port].
+func (d *Docs) requestServerURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	host := r.Host
+	if d.config.TrustForwardedHeaders {
+		if proto := strings.ToLower(forwardedValue(r.Header.Get("X-Forwarded-Proto"))); proto == "http" || proto == "https" {
+			scheme = proto
+		}
+		if forwarded := forwardedValue(r.Header.Get("X-Forwarded-Host")); forwarded != "" {
+			host = forwarded
+		}
+	}
+	if !validHost.MatchString(host) {
+		return ""
+	}
+
+	basePath := strings.TrimSuffix(d.config.ServerBasePath, "/")
+	if basePath != "" && !strings.HasPrefix(basePath, "/") {
+		basePath = "/" + basePath
+	}
+	return scheme + "://" + host + basePath
+}
+
+// validHost matches a host name, IPv4 or bracketed IPv6 address with an
+// optional port
+var validHost = regexp.MustCompile(`^(?:[A-Za-z0-9](?:[A-Za-z0-9.-]*[A-Za-z0-9])?|\[[0-9A-Fa-f:.]+\])(?::[0-9]{1,5})?$`)
+
+// forwardedValue returns the first (client-facing) entry of a comma-separated
+// X-Forwarded-* header
+func forwardedValue(header string) string {
+	first, _, _ := strings.Cut(header, ",")
+	return strings.TrimSpace(first)
+}
+
+// withServer returns a copy of the spec with url as its first server,
+// keeping the configured servers after it
+func withServer(openapi *spec.OpenAPI, url string) *spec.OpenAPI {
+	copied := *openapi
+	copied.Servers = []spec.Server{{URL: url, Description: "Current host"}}
+	for _, srv := range openapi.Servers {
+		if srv.URL != url {
+			copied.Servers = append(copied.Servers, srv)
+		}
+	}
+	return &copied
+}
+
+// isEndpointServer reports whether target shares its scheme and host with a
+// server of an endpoint (Endpoint.Servers), so Try-It requests to endpoints
+// with their own host can be proxied
+func (d *Docs) isEndpointServer(target *url.URL) bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	for _, ep := range d.endpoints {
+		for _, srv := range ep.Servers {
+			u, err := url.Parse(srv.URL)
+			if err == nil && u.Host != "" && strings.EqualFold(u.Scheme, target.Scheme) && strings.EqualFold(u.Host, target.Host) {
+				return true
+			}
+		}
+	}
+	return false
+}
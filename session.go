@@ -0,0 +1,73 @@
+package openswag
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// SessionCookieName is the cookie that carries the docs auth session
+	SessionCookieName = "openswag_session"
+
+	defaultSessionTTL = time.Hour
+)
+
+// grantSession sets a signed session cookie if sessions are enabled
+func (d *Docs) grantSession(w http.ResponseWriter, r *http.Request) {
+	secret := d.config.DocsAuth.SessionSecret
+	if secret == "" {
+		return
+	}
+
+	ttl := d.config.DocsAuth.SessionTTL
+	if ttl <= 0 {
+		ttl = defaultSessionTTL
+	}
+	expires := time.Now().Add(ttl)
+	value := strconv.FormatInt(expires.Unix(), 10)
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     SessionCookieName,
+		Value:    value + "." + signSession(secret, value),
+		Path:     "/",
+		Expires:  expires,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// validSession reports whether the request carries an unexpired, correctly signed session cookie
+func (d *Docs) validSession(r *http.Request) bool {
+	secret := d.config.DocsAuth.SessionSecret
+	if secret == "" {
+		return false
+	}
+
+	cookie, err := r.Cookie(SessionCookieName)
+	if err != nil {
+		return false
+	}
+
+	value, signature, ok := strings.Cut(cookie.Value, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(signSession(secret, value))) {
+		return false
+	}
+
+	expires, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return false
+	}
+	return time.Now().Unix() < expires
+}
+
+func signSession(secret, value string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,32 @@
+package openswag
+
+import (
+	"time"
+
+	"github.com/andrianprasetya/open-swag-go/pkg/spec"
+)
+
+// SLA documents the expected latency of an endpoint
+type SLA struct {
+	P50 time.Duration
+	P99 time.Duration
+}
+
+// applySLA adds the x-sla extension, with latencies written as durations
+// such as "120ms"
+func applySLA(ep Endpoint, op *spec.Operation) {
+	if ep.SLA == nil {
+		return
+	}
+
+	sla := make(map[string]string)
+	if ep.SLA.P50 > 0 {
+		sla["p50"] = ep.SLA.P50.String()
+	}
+	if ep.SLA.P99 > 0 {
+		sla["p99"] = ep.SLA.P99.String()
+	}
+	if len(sla) > 0 {
+		op.WithExtension("x-sla", sla)
+	}
+}
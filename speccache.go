@@ -0,0 +1,101 @@
+package openswag
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+)
+
+// DefaultSpecCacheSize is the number of served spec variants kept when
+// Config.SpecCacheSize is zero
+const DefaultSpecCacheSize = 32
+
+// specCache is an LRU of marshalled spec responses keyed by the request's
+// variant (scope, tags, language, pretty printing, server URL)
+type specCache struct {
+	mu         sync.Mutex
+	size       int
+	generation uint64
+	order      *list.List
+	entries    map[string]*list.Element
+}
+
+// cachedSpec is a marshalled spec with its ETag
+type cachedSpec struct {
+	key  string
+	body []byte
+	etag string
+}
+
+func newSpecCache(size int) *specCache {
+	if size < 0 {
+		return nil
+	}
+	if size == 0 {
+		size = DefaultSpecCacheSize
+	}
+	return &specCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// get returns the cached variant for key and the current generation, which
+// put needs to reject entries built before an invalidation
+func (c *specCache) get(key string) (*cachedSpec, uint64) {
+	if c == nil {
+		return nil, 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		c.order.MoveToFront(el)
+		return el.Value.(*cachedSpec), c.generation
+	}
+	return nil, c.generation
+}
+
+// put stores body under key, evicting the least recently used variant
+func (c *specCache) put(key string, body []byte, generation uint64) *cachedSpec {
+	sum := sha256.Sum256(body)
+	entry := &cachedSpec{key: key, body: body, etag: `"` + hex.EncodeToString(sum[:16]) + `"`}
+	if c == nil {
+		return entry
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		// The docs changed while this variant was being built
+		return entry
+	}
+	if el, ok := c.entries[key]; ok {
+		el.Value = entry
+		c.order.MoveToFront(el)
+		return entry
+	}
+	c.entries[key] = c.order.PushFront(entry)
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cachedSpec).key)
+	}
+	return entry
+}
+
+// clear drops every cached variant
+func (c *specCache) clear() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.order.Init()
+	c.entries = make(map[string]*list.Element)
+}
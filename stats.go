@@ -0,0 +1,140 @@
+package openswag
+
+import (
+	"strings"
+
+	"github.com/andrianprasetya/open-swag-go/pkg/spec"
+)
+
+// SpecStats summarizes the size and shape of the public spec
+type SpecStats struct {
+	Operations int `json:"operations"`
+	// ByMethod counts operations per upper-case HTTP method
+	ByMethod map[string]int `json:"byMethod"`
+	// ByTag counts operations per tag; an operation counts once for each
+	// of its tags and untagged operations are not counted
+	ByTag map[string]int `json:"byTag"`
+	// Schemas counts the distinct named schemas used by the operations,
+	// webhooks and components: titled schemas (one per Go struct type) and
+	// component schemas, however often they appear
+	Schemas    int `json:"schemas"`
+	Deprecated int `json:"deprecated"`
+	// Secured counts operations requiring any security scheme, globally or
+	// on the operation; Public counts the rest
+	Secured int `json:"secured"`
+	Public  int `json:"public"`
+	// AvgParameters is the mean number of parameters per operation,
+	// including parameters declared on the path item
+	AvgParameters float64 `json:"avgParameters"`
+}
+
+// Stats computes summary statistics from the public spec
+func (d *Docs) Stats() SpecStats {
+	openapi := d.BuildSpec()
+
+	stats := SpecStats{
+		ByMethod: map[string]int{},
+		ByTag:    map[string]int{},
+	}
+	names := make(map[string]bool)
+	if openapi.Components != nil {
+		for name, s := range openapi.Components.Schemas {
+			names[name] = true
+			schemaNames(s, names)
+		}
+	}
+	for _, item := range openapi.Webhooks {
+		for _, method := range spec.Methods {
+			if op := item.Operation(method); op != nil {
+				operationSchemaNames(item, op, names)
+			}
+		}
+	}
+
+	params := 0
+	for _, item := range openapi.Paths {
+		for _, method := range spec.Methods {
+			op := item.Operation(method)
+			if op == nil {
+				continue
+			}
+			operationSchemaNames(item, op, names)
+			stats.Operations++
+			stats.ByMethod[method]++
+			for _, tag := range op.Tags {
+				stats.ByTag[tag]++
+			}
+			if op.Deprecated {
+				stats.Deprecated++
+			}
+
+			security := openapi.Security
+			if op.Security != nil {
+				security = op.Security
+			}
+			if len(security) > 0 {
+				stats.Secured++
+			} else {
+				stats.Public++
+			}
+
+			params += len(item.Parameters) + len(op.Parameters)
+		}
+	}
+	if stats.Operations > 0 {
+		stats.AvgParameters = float64(params) / float64(stats.Operations)
+	}
+	stats.Schemas = len(names)
+	return stats
+}
+
+// operationSchemaNames adds the names of the schemas an operation uses in
+// its parameters, request body and responses
+func operationSchemaNames(item *spec.PathItem, op *spec.Operation, names map[string]bool) {
+	for _, params := range [][]*spec.Parameter{item.Parameters, op.Parameters} {
+		for _, p := range params {
+			schemaNames(p.Schema, names)
+			contentSchemaNames(p.Content, names)
+		}
+	}
+	if op.RequestBody != nil {
+		contentSchemaNames(op.RequestBody.Content, names)
+	}
+	for _, resp := range op.Responses {
+		contentSchemaNames(resp.Content, names)
+		for _, h := range resp.Headers {
+			schemaNames(h.Schema, names)
+		}
+	}
+}
+
+func contentSchemaNames(content map[string]*spec.MediaType, names map[string]bool) {
+	for _, media := range content {
+		schemaNames(media.Schema, names)
+	}
+}
+
+// schemaNames adds the title and the referenced component of s and its
+// subschemas to names
+func schemaNames(s *spec.Schema, names map[string]bool) {
+	if s == nil {
+		return
+	}
+	if s.Title != "" {
+		names[s.Title] = true
+	}
+	if name, ok := strings.CutPrefix(s.Ref, "#/components/schemas/"); ok {
+		names[name] = true
+	}
+	for _, prop := range s.Properties {
+		schemaNames(prop, names)
+	}
+	for _, list := range [][]*spec.Schema{s.AllOf, s.OneOf, s.AnyOf} {
+		for _, sub := range list {
+			schemaNames(sub, names)
+		}
+	}
+	schemaNames(s.Items, names)
+	schemaNames(s.AdditionalProperties, names)
+	schemaNames(s.Not, names)
+}
@@ -0,0 +1,42 @@
+package openswag
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/andrianprasetya/open-swag-go/pkg/spec"
+)
+
+// applyTimeout documents a long-running endpoint's expected timeout as the
+// x-timeout-ms extension and a note in the description
+func applyTimeout(ep Endpoint, op *spec.Operation) {
+	if ep.Timeout <= 0 {
+		return
+	}
+
+	op.WithExtension("x-timeout-ms", ep.Timeout.Milliseconds())
+	if op.Description != "" {
+		op.Description += "\n\n"
+	}
+	op.Description += fmt.Sprintf("This endpoint may take up to %s; configure client timeouts accordingly.", ep.Timeout)
+}
+
+// endpointTimeout returns the Timeout of the endpoint a Try-It request
+// targets, or zero to keep the console's default. Leading segments of the
+// target path are dropped one by one so server base paths such as /api/v1
+// still match.
+func (d *Docs) endpointTimeout(method string, target *url.URL) time.Duration {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	parts := strings.Split(strings.Trim(target.Path, "/"), "/")
+	for len(parts) > 0 {
+		if i, ok := d.matchEndpoint(method, strings.Join(parts, "/")); ok {
+			return d.endpoints[i].Timeout
+		}
+		parts = parts[1:]
+	}
+	return 0
+}
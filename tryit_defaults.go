@@ -0,0 +1,150 @@
+package openswag
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/andrianprasetya/open-swag-go/pkg/spec"
+)
+
+// TryItDefaults returns the values a Try-It console can prefill, keyed by
+// operationId. Each entry holds "parameters", mapping parameter names to
+// their default or example, "body", a request body assembled from the
+// schema's defaults and examples, and "server", the first of the endpoint's
+// own servers when it overrides them.
+func (d *Docs) TryItDefaults() map[string]interface{} {
+	openapi := d.BuildSpec()
+	defaults := make(map[string]interface{})
+
+	for _, item := range openapi.Paths {
+		for _, method := range spec.Methods {
+			op := item.Operation(method)
+			if op == nil || op.OperationID == "" {
+				continue
+			}
+
+			entry := make(map[string]interface{})
+			params := make(map[string]interface{})
+			for _, p := range op.Parameters {
+				if value := parameterDefault(p); value != nil {
+					params[p.Name] = value
+				}
+			}
+			if len(params) > 0 {
+				entry["parameters"] = params
+			}
+			if body := requestBodyDefault(openapi, op.RequestBody); body != nil {
+				entry["body"] = body
+			}
+			// Endpoints overriding the servers are sent to their own host
+			if len(op.Servers) > 0 {
+				entry["server"] = op.Servers[0].URL
+			}
+			if len(entry) > 0 {
+				defaults[op.OperationID] = entry
+			}
+		}
+	}
+	return defaults
+}
+
+// parameterDefault prefers the schema default over the parameter's example,
+// then its named examples
+func parameterDefault(p *spec.Parameter) interface{} {
+	if p.Schema != nil && p.Schema.Default != nil {
+		return p.Schema.Default
+	}
+	if p.Example != nil {
+		return p.Example
+	}
+	// Named examples: the first by name, since maps have no order
+	names := make([]string, 0, len(p.Examples))
+	for name, example := range p.Examples {
+		if example != nil && example.Value != nil {
+			names = append(names, name)
+		}
+	}
+	if len(names) > 0 {
+		sort.Strings(names)
+		return p.Examples[names[0]].Value
+	}
+	if p.Schema != nil {
+		return p.Schema.Example
+	}
+	return nil
+}
+
+// requestBodyDefault returns the body example, or one assembled from the
+// schema, of the JSON content (or the first content type)
+func requestBodyDefault(openapi *spec.OpenAPI, body *spec.RequestBody) interface{} {
+	if body == nil {
+		return nil
+	}
+	media := body.Content[ContentTypeJSON]
+	if media == nil {
+		for _, m := range body.Content {
+			media = m
+			break
+		}
+	}
+	if media == nil {
+		return nil
+	}
+	if media.Example != nil {
+		return media.Example
+	}
+	return schemaDefault(openapi, media.Schema, 0)
+}
+
+// schemaDefault builds a value from a schema's defaults and examples,
+// descending into objects, arrays, allOf and component references (which
+// are left out without a spec to resolve them in)
+func schemaDefault(openapi *spec.OpenAPI, s *spec.Schema, depth int) interface{} {
+	if s == nil || depth > 8 {
+		return nil
+	}
+	if s.Ref != "" {
+		if openapi == nil {
+			return nil
+		}
+		resolved, err := openapi.SchemaAt(strings.TrimPrefix(s.Ref, "#"))
+		if err != nil {
+			return nil
+		}
+		return schemaDefault(openapi, resolved, depth+1)
+	}
+	if s.Default != nil {
+		return s.Default
+	}
+
+	switch {
+	case len(s.AllOf) > 0:
+		merged := make(map[string]interface{})
+		for _, sub := range s.AllOf {
+			if obj, ok := schemaDefault(openapi, sub, depth+1).(map[string]interface{}); ok {
+				for name, value := range obj {
+					merged[name] = value
+				}
+			}
+		}
+		return merged
+	case len(s.Properties) > 0:
+		obj := make(map[string]interface{})
+		for name, prop := range s.Properties {
+			if prop.ReadOnly {
+				continue
+			}
+			if value := schemaDefault(openapi, prop, depth+1); value != nil {
+				obj[name] = value
+			}
+		}
+		return obj
+	case s.Example != nil:
+		return s.Example
+	case s.Type == "array":
+		if item := schemaDefault(openapi, s.Items, depth+1); item != nil {
+			return []interface{}{item}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package openswag
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/andrianprasetya/open-swag-go/pkg/spec"
+)
+
+// Validate checks the registered endpoints for documentation mistakes that
+// BuildSpec would otherwise silently skip
+func (d *Docs) Validate() []error {
+	if _, err := d.buildSpec(true); err != nil {
+		return []error{err}
+	}
+
+	// The structure check only knows OpenAPI 3.1, so 3.0 documents skip it
+	var strictErrs []error
+	if d.config.StrictValidation && !strings.HasPrefix(d.config.OpenAPIVersion, "3.0") {
+		strictErrs = d.ValidateAgainstMetaSchema()
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	errs := append([]error(nil), d.patchErrors...)
+	errs = append(errs, d.operationIDCollisions()...)
+	for _, ep := range d.endpoints {
+		if !spec.IsMethod(ep.Method) {
+			errs = append(errs, fmt.Errorf("%s: unknown HTTP method %q", ep.Path, ep.Method))
+			continue
+		}
+		for _, err := range d.validatePathParams(ep) {
+			errs = append(errs, fmt.Errorf("%s %s: %w", ep.Method, ep.Path, err))
+		}
+		for _, param := range ep.Parameters {
+			if param.Example != nil && len(param.Examples) > 0 {
+				errs = append(errs, fmt.Errorf("%s %s: parameter %q sets both Example and Examples", ep.Method, ep.Path, param.Name))
+			}
+			if (param.AllowEmptyValue || param.AllowReserved) && param.In != "query" {
+				errs = append(errs, fmt.Errorf("%s %s: parameter %q sets AllowEmptyValue or AllowReserved outside the query", ep.Method, ep.Path, param.Name))
+			}
+		}
+		if sunset := sunsetDate(ep); sunset != "" {
+			if err := ValidateSunsetDate(sunset); err != nil {
+				errs = append(errs, fmt.Errorf("%s %s: %w", ep.Method, ep.Path, err))
+			}
+		}
+	}
+	return append(errs, strictErrs...)
+}
+
+// ValidateAgainstMetaSchema checks the full spec (including internal
+// endpoints) with spec.ValidateStructure, catching problems the per-endpoint
+// checks don't, such as invalid schema types or status codes. The embedded
+// schema is a subset of the official OpenAPI 3.1 meta-schema: it checks
+// required fields, allowed keys and enumerated values but not the contents
+// of Schema Objects. Documents configured for OpenAPI 3.0 are reported as
+// such.
+func (d *Docs) ValidateAgainstMetaSchema() []error {
+	openapi, err := d.buildSpec(true)
+	if err != nil {
+		return []error{err}
+	}
+	if !strings.HasPrefix(openapi.OpenAPI, "3.1") {
+		return []error{fmt.Errorf("structure validation requires OpenAPI 3.1, the spec declares %s", openapi.OpenAPI)}
+	}
+
+	data, err := json.Marshal(openapi)
+	if err != nil {
+		return []error{err}
+	}
+	return spec.ValidateStructure(data)
+}
+
+// validatePathParams reports declared path parameters that have no matching
+// placeholder in the path template, e.g. PathParam("id") on /users/{userId}
+func (d *Docs) validatePathParams(ep Endpoint) []error {
+	placeholders := make(map[string]bool)
+	for _, name := range extractPathParams(ep.Path) {
+		placeholders[name] = true
+	}
+
+	var declared []string
+	for _, param := range ep.Parameters {
+		if param.In == "path" {
+			declared = append(declared, param.Name)
+		}
+	}
+	if ep.PathParams != nil {
+		for _, param := range d.buildParamsFromStruct(ep.PathParams, "path") {
+			declared = append(declared, param.Name)
+		}
+	}
+
+	var errs []error
+	for _, name := range declared {
+		if !placeholders[name] {
+			errs = append(errs, fmt.Errorf("path parameter %q is not in the path template", name))
+		}
+	}
+	return errs
+}
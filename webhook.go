@@ -0,0 +1,169 @@
+package openswag
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/andrianprasetya/open-swag-go/pkg/asyncapi"
+	"github.com/andrianprasetya/open-swag-go/pkg/schema"
+	"github.com/andrianprasetya/open-swag-go/pkg/spec"
+)
+
+// Webhook documents a request the API sends to subscribers when an event occurs
+type Webhook struct {
+	Method      string // defaults to POST
+	Summary     string
+	Description string
+	Tags        []string
+	// Payload is the body delivered to the subscriber
+	Payload interface{}
+	// Responses are the responses expected from the subscriber's endpoint,
+	// not responses sent by this API (e.g. 200 to acknowledge delivery)
+	Responses map[int]Response
+	// Retry documents how failed deliveries are retried (x-webhook-retry)
+	Retry *RetryPolicy
+}
+
+// RetryPolicy describes the redelivery of webhooks the subscriber didn't acknowledge
+type RetryPolicy struct {
+	MaxAttempts int
+	// Timeout is how long the subscriber has to respond
+	Timeout time.Duration
+	// Backoff names the delay strategy between attempts (e.g. "exponential")
+	Backoff string
+	// RetryOn lists what triggers a retry (e.g. "5xx", "timeout")
+	RetryOn []string
+}
+
+// AddWebhook registers a webhook under its event name
+func (d *Docs) AddWebhook(name string, webhook Webhook) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.webhooks == nil {
+		d.webhooks = make(map[string]Webhook)
+	}
+	d.webhooks[name] = webhook
+	d.invalidate()
+}
+
+// AsyncAPI documents the registered webhooks as an AsyncAPI 2.x document:
+// each webhook becomes a channel named after its event, with a subscribe
+// operation carrying the payload, so webhooks and other event contracts can
+// be described from the same Go types as the REST API
+func (d *Docs) AsyncAPI() *asyncapi.Document {
+	doc := asyncapi.New(asyncapi.Info{
+		Title:       d.config.Info.Title,
+		Version:     d.config.Info.Version,
+		Description: d.config.Info.Description,
+	}).WithSchemaOptions(schema.Options{
+		FieldNaming: d.config.FieldNaming,
+		MaxDepth:    d.config.MaxSchemaDepth,
+	})
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	for name, wh := range d.webhooks {
+		doc.Subscribe(name, asyncapi.Event{
+			Name:        name,
+			Summary:     wh.Summary,
+			Description: wh.Description,
+			Tags:        wh.Tags,
+			Payload:     wh.Payload,
+		})
+	}
+	return doc
+}
+
+// addWebhooksToSpec adds the registered webhooks; callers must hold d.mu
+func (d *Docs) addWebhooksToSpec(openapi *spec.OpenAPI) {
+	for name, wh := range d.webhooks {
+		method := wh.Method
+		if method == "" {
+			method = "POST"
+		}
+		openapi.AddWebhook(name, spec.NewPathItem().SetOperation(method, d.buildWebhook(wh)))
+	}
+}
+
+func (d *Docs) buildWebhook(wh Webhook) *spec.Operation {
+	var notes []string
+	if len(wh.Responses) > 0 {
+		notes = append(notes, "the responses below are what your endpoint is expected to return.")
+	}
+	if wh.Retry != nil {
+		notes = append(notes, wh.Retry.describe())
+	}
+	description := wh.Description
+	if len(notes) > 0 {
+		if description != "" {
+			description += "\n\n"
+		}
+		description += "**Receiving this webhook:** " + strings.Join(notes, " ")
+	}
+
+	op := spec.NewOperation(wh.Summary).
+		WithDescription(description).
+		WithTags(wh.Tags...)
+
+	if wh.Payload != nil {
+		op.WithRequestBody(spec.NewRequestBody("Event payload", true).
+			WithJSONContent(d.schemaFor(wh.Payload)))
+	}
+
+	for code, resp := range wh.Responses {
+		r := spec.NewResponse(d.responseDescription(code, resp.Description))
+		if resp.Schema != nil {
+			contentType := resp.ContentType
+			if contentType == "" {
+				contentType = ContentTypeJSON
+			}
+			r.WithContent(contentType, d.schemaForMedia(resp.Schema, contentType))
+		}
+		op.AddResponse(intToString(code), r)
+	}
+
+	if wh.Retry != nil {
+		op.WithExtension("x-webhook-retry", wh.Retry.extension())
+	}
+	return op
+}
+
+// describe explains the retry policy to subscribers in one sentence
+func (p *RetryPolicy) describe() string {
+	var sb strings.Builder
+	sb.WriteString("Deliveries")
+	if p.Timeout > 0 {
+		sb.WriteString(" time out after " + p.Timeout.String() + " and")
+	}
+	sb.WriteString(" are retried")
+	if p.MaxAttempts > 0 {
+		sb.WriteString(fmt.Sprintf(" up to %d times", p.MaxAttempts))
+	}
+	if p.Backoff != "" {
+		sb.WriteString(" with " + p.Backoff + " backoff")
+	}
+	if len(p.RetryOn) > 0 {
+		sb.WriteString(" on " + strings.Join(p.RetryOn, ", "))
+	}
+	sb.WriteString(".")
+	return sb.String()
+}
+
+// extension is the x-webhook-retry value, with the timeout written as a duration
+func (p *RetryPolicy) extension() map[string]interface{} {
+	ext := make(map[string]interface{})
+	if p.MaxAttempts > 0 {
+		ext["maxAttempts"] = p.MaxAttempts
+	}
+	if p.Timeout > 0 {
+		ext["timeout"] = p.Timeout.String()
+	}
+	if p.Backoff != "" {
+		ext["backoff"] = p.Backoff
+	}
+	if len(p.RetryOn) > 0 {
+		ext["retryOn"] = p.RetryOn
+	}
+	return ext
+}